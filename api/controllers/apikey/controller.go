@@ -0,0 +1,117 @@
+package apikey
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/authz"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	apiKeyUsecase usecases.ApiKeyUsecase
+}
+
+func NewApiKeyController(apiKeyUsecase usecases.ApiKeyUsecase) *Controller {
+	return &Controller{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+func (c *Controller) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var createKey CreateApiKey
+	if err := json.NewDecoder(r.Body).Decode(&createKey); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&createKey); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	for _, permission := range createKey.Permissions {
+		if err := validation.ValidateOneOf(authz.ApiKeyActionList, permission, "permissions"); err != nil {
+			responses.ErrorHandler(w, err)
+			return
+		}
+	}
+
+	resp, err := c.apiKeyUsecase.CreateApiKey(ctx, username, orgID, createKey.Name, createKey.Permissions)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.apiKeyUsecase.GetApiKeys(ctx, username, orgID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	keyID, err := parsers.ParseVar(r, "apiKeyId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.apiKeyUsecase.RevokeApiKey(ctx, username, keyID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,6 @@
+package apikey
+
+type CreateApiKey struct {
+	Name        string   `json:"name" validate:"required,max=100"`
+	Permissions []string `json:"permissions" validate:"required,min=1"`
+}
@@ -0,0 +1,246 @@
+package attachment
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"net/http"
+)
+
+type Controller struct {
+	attachmentUsecase usecases.AttachmentUsecase
+}
+
+func NewAttachmentController(attachmentUsecase usecases.AttachmentUsecase) *Controller {
+	return &Controller{
+		attachmentUsecase: attachmentUsecase,
+	}
+}
+
+func (c *Controller) AddTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	upload, err := parseUpload(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.attachmentUsecase.AddTenderAttachment(ctx, username, tenderID, upload)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetTenderAttachments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.attachmentUsecase.GetTenderAttachments(ctx, username, tenderID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	attachmentID, err := parsers.ParseVar(r, "attachmentId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	attachment, content, err := c.attachmentUsecase.GetTenderAttachment(ctx, username, tenderID, attachmentID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+	defer content.Close()
+
+	writeAttachment(w, attachment, content)
+}
+
+func (c *Controller) RemoveTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	attachmentID, err := parsers.ParseVar(r, "attachmentId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := c.attachmentUsecase.RemoveTenderAttachment(ctx, username, tenderID, attachmentID); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.Ok(w, http.StatusNoContent)
+}
+
+func (c *Controller) AddBidAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	upload, err := parseUpload(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.attachmentUsecase.AddBidAttachment(ctx, username, bidID, upload)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetBidAttachments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.attachmentUsecase.GetBidAttachments(ctx, username, bidID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetBidAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	attachmentID, err := parsers.ParseVar(r, "attachmentId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	attachment, content, err := c.attachmentUsecase.GetBidAttachment(ctx, username, bidID, attachmentID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+	defer content.Close()
+
+	writeAttachment(w, attachment, content)
+}
+
+func (c *Controller) RemoveBidAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	attachmentID, err := parsers.ParseVar(r, "attachmentId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := c.attachmentUsecase.RemoveBidAttachment(ctx, username, bidID, attachmentID); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.Ok(w, http.StatusNoContent)
+}
@@ -0,0 +1,58 @@
+package attachment
+
+import (
+	"avito/api/validation"
+	"avito/internal/entity"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	uploadField   = "file"
+	maxNameLength = 255
+)
+
+// parseUpload find file part of multipart body, it is streamed without buffering whole file
+func parseUpload(r *http.Request) (*entity.Upload, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, validation.NewValidateError("multipart/form-data body required")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, validation.NewValidateError(uploadField + " required")
+		}
+		if err != nil {
+			return nil, validation.ErrParsed
+		}
+
+		if part.FormName() != uploadField {
+			continue
+		}
+
+		name := filepath.Base(part.FileName())
+		if name == "." || name == string(filepath.Separator) || !utf8.ValidString(name) || len(name) > maxNameLength {
+			return nil, validation.NewValidateError("invalid file name")
+		}
+
+		return &entity.Upload{Name: name, Content: part}, nil
+	}
+}
+
+func writeAttachment(w http.ResponseWriter, attachment *entity.Attachment, content io.Reader) {
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", strconv.Quote(attachment.SHA256))
+	w.WriteHeader(http.StatusOK)
+
+	io.Copy(w, content)
+}
@@ -57,13 +57,19 @@ func (c *Controller) GetMyBids(w http.ResponseWriter, r *http.Request) {
 
 	username, _ := parsers.ParseQuery(r, "username", false, parsers.ParserEmptyString)
 
-	resp, err := c.bidUsecase.GetMyBids(ctx, username, pagination)
+	listQuery, err := parsers.ParseListQuery(r, parsers.BidFields)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
 	}
 
-	responses.OkJSON(w, http.StatusOK, resp)
+	resp, err := c.bidUsecase.GetMyBids(ctx, username, pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
 }
 
 func (c *Controller) GetTenderBidsList(w http.ResponseWriter, r *http.Request) {
@@ -75,7 +81,7 @@ func (c *Controller) GetTenderBidsList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -87,13 +93,50 @@ func (c *Controller) GetTenderBidsList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := c.bidUsecase.GetTenderBidsList(ctx, username, tenderID, pagination)
+	listQuery, err := parsers.ParseListQuery(r, parsers.BidFields)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
 	}
 
-	responses.OkJSON(w, http.StatusOK, resp)
+	resp, err := c.bidUsecase.GetTenderBidsList(ctx, username, tenderID, pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) ExportTenderBids(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	format, err := parsers.ParseExportFormat(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	listQuery, err := parsers.ParseListQuery(r, parsers.BidFields)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	export := responses.NewExport(w, format, "bids", bidExportColumns, bidExportRow)
+	export.Finish(c.bidUsecase.ExportTenderBids(ctx, username, tenderID, listQuery, export.Write))
 }
 
 func (c *Controller) GetBidStatus(w http.ResponseWriter, r *http.Request) {
@@ -105,7 +148,7 @@ func (c *Controller) GetBidStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -129,7 +172,7 @@ func (c *Controller) UpdateBidStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -160,6 +203,35 @@ func (c *Controller) UpdateBidStatus(w http.ResponseWriter, r *http.Request) {
 	responses.OkJSON(w, http.StatusOK, resp)
 }
 
+func (c *Controller) UpdateBidsStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var batch BatchBidStatus
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&batch); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.bidUsecase.UpdateBidsStatus(ctx, username, batch.IDs, batch.Status, batch.Atomic)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkBatchJSON(w, resp)
+}
+
 func (c *Controller) PatchBid(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -169,7 +241,7 @@ func (c *Controller) PatchBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -206,7 +278,7 @@ func (c *Controller) SubmitDecisionBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -231,6 +303,35 @@ func (c *Controller) SubmitDecisionBid(w http.ResponseWriter, r *http.Request) {
 	responses.OkJSON(w, http.StatusOK, resp)
 }
 
+func (c *Controller) SubmitDecisionBids(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var batch BatchDecision
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&batch); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.bidUsecase.SubmitDecisions(ctx, username, batch.IDs, batch.Decision, batch.Atomic)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkBatchJSON(w, resp)
+}
+
 func (c *Controller) FeedbackBid(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -240,7 +341,7 @@ func (c *Controller) FeedbackBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -252,7 +353,72 @@ func (c *Controller) FeedbackBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := c.bidUsecase.FeedbackBid(ctx, username, bidID, feedback)
+	rating, err := parseRating(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.bidUsecase.FeedbackBid(ctx, username, bidID, feedback, rating)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) WithdrawBid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var withdrawBid WithdrawBid
+	if err := json.NewDecoder(r.Body).Decode(&withdrawBid); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&withdrawBid); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.bidUsecase.WithdrawBid(ctx, username, bidID, withdrawBid.Reason)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) ResubmitBid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.bidUsecase.ResubmitBid(ctx, username, bidID)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -261,6 +427,36 @@ func (c *Controller) FeedbackBid(w http.ResponseWriter, r *http.Request) {
 	responses.OkJSON(w, http.StatusOK, resp)
 }
 
+func (c *Controller) GetBidHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.bidUsecase.GetBidHistory(ctx, username, bidID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
 func (c *Controller) RollbackBid(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -276,7 +472,7 @@ func (c *Controller) RollbackBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -318,11 +514,90 @@ func (c *Controller) PrevRewiews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := c.bidUsecase.CheckPrevFeedbacks(ctx, tenderID, authorUsername, requesterUsername, *pagination)
+	listQuery, err := parsers.ParseListQuery(r, parsers.ReviewFields)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
 	}
 
-	responses.OkJSON(w, http.StatusOK, resp)
+	resp, err := c.bidUsecase.CheckPrevFeedbacks(ctx, tenderID, authorUsername, requesterUsername, *pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) ExportPrevRewiews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	format, err := parsers.ParseExportFormat(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	authorUsername, err := parsers.ParseQuery(r, "authorUsername", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	requesterUsername, err := parsers.ParseQuery(r, "requesterUsername", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	listQuery, err := parsers.ParseListQuery(r, parsers.ReviewFields)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	export := responses.NewExport(w, format, "reviews", reviewExportColumns, reviewExportRow)
+	export.Finish(c.bidUsecase.ExportPrevFeedbacks(ctx, tenderID, authorUsername, requesterUsername, listQuery, export.Write))
+}
+
+// parseRating parse optional scores of review, absent score is not rated
+func parseRating(r *http.Request) (entity.ReviewRating, error) {
+	quality, err := parsers.ParseQuery(r, "quality", false, parsers.ParserInt)
+	if err != nil {
+		return entity.ReviewRating{}, err
+	}
+
+	timeliness, err := parsers.ParseQuery(r, "timeliness", false, parsers.ParserInt)
+	if err != nil {
+		return entity.ReviewRating{}, err
+	}
+
+	communication, err := parsers.ParseQuery(r, "communication", false, parsers.ParserInt)
+	if err != nil {
+		return entity.ReviewRating{}, err
+	}
+
+	scores := FeedbackRating{Quality: quality, Timeliness: timeliness, Communication: communication}
+	if err := validation.ValidateStruct(&scores); err != nil {
+		return entity.ReviewRating{}, err
+	}
+
+	return entity.ReviewRating{
+		Quality:       ratingScore(quality),
+		Timeliness:    ratingScore(timeliness),
+		Communication: ratingScore(communication),
+	}, nil
+}
+
+func ratingScore(score int) *int {
+	if score == 0 {
+		return nil
+	}
+	return &score
 }
@@ -0,0 +1,60 @@
+package bid
+
+import (
+	"avito/api/responses"
+	"avito/internal/entity"
+	"strconv"
+)
+
+var bidExportColumns = []string{
+	"id", "name", "description", "status", "tenderId", "authorType", "authorId", "authorLabel",
+	"version", "createdAt", "decision", "withdrawReason", "resubmissions", "sealed",
+}
+
+// bidExportRow leave author empty when it is replaced by pseudonym
+func bidExportRow(b *entity.Bid) []string {
+	authorID := ""
+	if b.AuthorLabel == "" {
+		authorID = b.AuthorID.String()
+	}
+	return []string{
+		b.Id.String(),
+		b.Name,
+		b.Description,
+		string(b.Status),
+		b.TenderID.String(),
+		string(b.AuthorType),
+		authorID,
+		b.AuthorLabel,
+		strconv.Itoa(b.Version),
+		responses.CSVTime(&b.CreatedAt),
+		string(b.Decision),
+		b.WithdrawReason,
+		strconv.Itoa(b.Resubmissions),
+		strconv.FormatBool(b.Sealed),
+	}
+}
+
+var reviewExportColumns = []string{
+	"id", "description", "quality", "timeliness", "communication",
+	"reply", "repliedAt", "moderationStatus", "createdAt", "updatedAt",
+}
+
+func reviewExportRow(r *entity.BidRewiew) []string {
+	reply := ""
+	if r.Reply != nil {
+		reply = *r.Reply
+	}
+	return []string{
+		r.Id.String(),
+		r.Description,
+		responses.CSVInt(r.Quality),
+		responses.CSVInt(r.Timeliness),
+		responses.CSVInt(r.Communication),
+		reply,
+		responses.CSVTime(r.RepliedAt),
+		string(r.ModerationStatus),
+		responses.CSVTime(&r.CreatedAt),
+		responses.CSVTime(r.UpdatedAt),
+	}
+}
@@ -18,3 +18,25 @@ type PatchBid struct {
 	Name        string `json:"name" validate:"max=100"`
 	Description string `json:"description" validate:"max=500"`
 }
+
+type WithdrawBid struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type FeedbackRating struct {
+	Quality       int `validate:"omitempty,min=1,max=5"`
+	Timeliness    int `validate:"omitempty,min=1,max=5"`
+	Communication int `validate:"omitempty,min=1,max=5"`
+}
+
+type BatchBidStatus struct {
+	IDs    uuid.UUIDs           `json:"ids" validate:"required,min=1,max=100,unique"`
+	Status entity.BidStatusType `json:"status" validate:"required,oneof=Created Published Canceled"`
+	Atomic bool                 `json:"atomic"`
+}
+
+type BatchDecision struct {
+	IDs      uuid.UUIDs             `json:"ids" validate:"required,min=1,max=100,unique"`
+	Decision entity.BidDecisionType `json:"decision" validate:"required,oneof=Approved Rejected"`
+	Atomic   bool                   `json:"atomic"`
+}
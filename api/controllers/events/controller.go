@@ -0,0 +1,133 @@
+package events
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/internal/entity"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+type Controller struct {
+	streamUsecase usecases.EventStreamUsecase
+}
+
+func NewEventsController(streamUsecase usecases.EventStreamUsecase) *Controller {
+	return &Controller{
+		streamUsecase: streamUsecase,
+	}
+}
+
+// Stream push events visible to user as server-sent events until client disconnects
+func (c *Controller) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	eventTypes, err := parsers.ParseEventTypes(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	serviceTypes, err := parsers.ParseServiceTypes(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	lastEventID, err := parsers.ParseLastEventID(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		responses.ErrorHandler(w, fmt.Errorf("streaming unsupported"))
+		return
+	}
+
+	sub, err := c.streamUsecase.Subscribe(ctx, username, entity.StreamFilter{EventTypes: eventTypes, ServiceTypes: serviceTypes}, lastEventID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+	defer c.streamUsecase.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	var lastSent int64
+	if lastEventID != nil {
+		lastSent = *lastEventID
+	}
+
+	if sub.Reset != nil {
+		// client resumes after reset id once it reloaded state
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: reset\ndata: {}\n\n", *sub.Reset); err != nil {
+			return
+		}
+		lastSent = *sub.Reset
+	}
+
+	for _, event := range sub.Replay {
+		if err := writeEvent(w, &event); err != nil {
+			return
+		}
+		lastSent = event.Seq
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			// stream is closed when access is lost, client reconnects and gets error status
+			if err := c.streamUsecase.Refresh(ctx, sub); err != nil {
+				return
+			}
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			// replay and live events can overlap
+			if event.Seq <= lastSent {
+				continue
+			}
+			if err := writeEvent(w, &event); err != nil {
+				return
+			}
+			lastSent = event.Seq
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event *entity.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+	return err
+}
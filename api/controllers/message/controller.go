@@ -0,0 +1,133 @@
+package message
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	messageUsecase usecases.MessageUsecase
+}
+
+func NewMessageController(messageUsecase usecases.MessageUsecase) *Controller {
+	return &Controller{
+		messageUsecase: messageUsecase,
+	}
+}
+
+func (c *Controller) SendMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var sendMessage SendMessage
+	if err := json.NewDecoder(r.Body).Decode(&sendMessage); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&sendMessage); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.messageUsecase.SendMessage(ctx, username, bidID, sendMessage.Text)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.messageUsecase.GetMessages(ctx, username, bidID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.messageUsecase.MarkRead(ctx, username, bidID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.messageUsecase.GetUnreadCount(ctx, username, bidID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
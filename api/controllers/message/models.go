@@ -0,0 +1,5 @@
+package message
+
+type SendMessage struct {
+	Text string `json:"text" validate:"required,max=2000"`
+}
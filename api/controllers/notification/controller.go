@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/entity"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	notificationUsecase usecases.NotificationUsecase
+}
+
+func NewNotificationController(notificationUsecase usecases.NotificationUsecase) *Controller {
+	return &Controller{
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+func (c *Controller) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	unreadOnly, err := parsers.ParseQuery(r, "unread", false, parsers.ParserBool)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.notificationUsecase.GetNotifications(ctx, username, unreadOnly, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) GetNotificationCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.notificationUsecase.GetNotificationCount(ctx, username)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	notificationID, err := parsers.ParseVar(r, "notificationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.notificationUsecase.MarkRead(ctx, username, notificationID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.notificationUsecase.MarkAllRead(ctx, username)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.notificationUsecase.GetPreferences(ctx, username)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) SetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var setPrefs []SetPreference
+	if err := json.NewDecoder(r.Body).Decode(&setPrefs); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	prefs := make([]entity.NotificationPreference, 0, len(setPrefs))
+	for i := range setPrefs {
+		if err := validation.ValidateStruct(&setPrefs[i]); err != nil {
+			responses.ErrorHandler(w, err)
+			return
+		}
+		if err := validation.ValidateOneOf(entity.NotificationTypeList, setPrefs[i].Type, "type"); err != nil {
+			responses.ErrorHandler(w, err)
+			return
+		}
+		prefs = append(prefs, entity.NotificationPreference{
+			Type:    entity.NotificationType(setPrefs[i].Type),
+			Enabled: *setPrefs[i].Enabled,
+		})
+	}
+
+	resp, err := c.notificationUsecase.SetPreferences(ctx, username, prefs)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,6 @@
+package notification
+
+type SetPreference struct {
+	Type    string `json:"type" validate:"required"`
+	Enabled *bool  `json:"enabled" validate:"required"`
+}
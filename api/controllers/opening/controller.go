@@ -0,0 +1,67 @@
+package opening
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"net/http"
+)
+
+type Controller struct {
+	openingUsecase usecases.OpeningUsecase
+}
+
+func NewOpeningController(openingUsecase usecases.OpeningUsecase) *Controller {
+	return &Controller{
+		openingUsecase: openingUsecase,
+	}
+}
+
+// VoteOpening is human only, quorum is counted by responsibles
+func (c *Controller) VoteOpening(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.openingUsecase.VoteOpening(ctx, username, tenderID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetOpeningProtocol(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.openingUsecase.GetOpeningProtocol(ctx, username, tenderID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
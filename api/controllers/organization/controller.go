@@ -0,0 +1,411 @@
+package organization
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	orgUsecase usecases.OrganizationUsecase
+}
+
+func NewOrganizationController(orgUsecase usecases.OrganizationUsecase) *Controller {
+	return &Controller{
+		orgUsecase: orgUsecase,
+	}
+}
+
+func (c *Controller) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var createOrg CreateOrganization
+	if err := json.NewDecoder(r.Body).Decode(&createOrg); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&createOrg); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	org := utils.MustTransformObj[CreateOrganization, entity.Organization](&createOrg)
+
+	resp, err := c.orgUsecase.CreateOrganization(ctx, createOrg.CreatorUserName, org)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.GetOrganization(ctx, orgID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	listQuery, err := parsers.ParseListQuery(r, parsers.OrganizationFields)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.GetOrganizations(ctx, pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) GetMyOrganizations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	listQuery, err := parsers.ParseListQuery(r, parsers.OrganizationFields)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.GetMyOrganizations(ctx, username, pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) PatchOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var patchOrg PatchOrganization
+	if err := json.NewDecoder(r.Body).Decode(&patchOrg); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(patchOrg); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	patchOrgEnt := utils.MustTransformObj[PatchOrganization, entity.Organization](&patchOrg)
+
+	resp, err := c.orgUsecase.PatchOrganization(ctx, username, orgID, patchOrgEnt)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetResponsibles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.GetResponsibles(ctx, username, orgID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) RemoveResponsible(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	userID, err := parsers.ParseVar(r, "userId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := c.orgUsecase.RemoveResponsible(ctx, username, orgID, userID); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.Ok(w, http.StatusNoContent)
+}
+
+func (c *Controller) SetResponsibleRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	userID, err := parsers.ParseVar(r, "userId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	role, err := parsers.ParseQuery(r, "role", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := validation.ValidateOneOf(entity.OrganizationRoleList, role, "role"); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.SetResponsibleRole(ctx, username, orgID, userID, entity.OrganizationRole(role))
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) InviteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	invitee, err := parsers.ParseQuery(r, "invitee", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	role, err := parsers.ParseQuery(r, "role", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := validation.ValidateOneOf(entity.OrganizationRoleList, role, "role"); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.InviteUser(ctx, username, orgID, invitee, entity.OrganizationRole(role))
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetOrgInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.GetOrgInvitations(ctx, username, orgID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) GetMyInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.GetMyInvitations(ctx, username, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	c.resolveInvitation(w, r, entity.InvitationAccepted)
+}
+
+func (c *Controller) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	c.resolveInvitation(w, r, entity.InvitationDeclined)
+}
+
+func (c *Controller) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	c.resolveInvitation(w, r, entity.InvitationRevoked)
+}
+
+func (c *Controller) resolveInvitation(w http.ResponseWriter, r *http.Request, status entity.InvitationStatusType) {
+	ctx := r.Context()
+
+	invitationID, err := parsers.ParseVar(r, "invitationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.ResolveInvitation(ctx, username, invitationID, status)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgUsecase.GetAudit(ctx, username, orgID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
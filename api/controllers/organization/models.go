@@ -0,0 +1,18 @@
+package organization
+
+import (
+	"avito/internal/entity"
+)
+
+type CreateOrganization struct {
+	Name            string                  `json:"name" validate:"required,max=100"`
+	Description     string                  `json:"description" validate:"max=500"`
+	Type            entity.OrganizationType `json:"type" validate:"required,oneof=IE LLC JSC"`
+	CreatorUserName string                  `json:"creatorUsername" validate:"required" copier:"-"`
+}
+
+type PatchOrganization struct {
+	Name        string                  `json:"name" validate:"max=100"`
+	Description string                  `json:"description" validate:"max=500"`
+	Type        entity.OrganizationType `json:"type" validate:"omitempty,oneof=IE LLC JSC"`
+}
@@ -0,0 +1,103 @@
+package orgreview
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/entity"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	orgReviewUsecase usecases.OrgReviewUsecase
+}
+
+func NewOrgReviewController(orgReviewUsecase usecases.OrgReviewUsecase) *Controller {
+	return &Controller{
+		orgReviewUsecase: orgReviewUsecase,
+	}
+}
+
+func (c *Controller) ReviewOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bidID, err := parsers.ParseVar(r, "bidId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var reviewOrg ReviewOrganization
+	if err := json.NewDecoder(r.Body).Decode(&reviewOrg); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&reviewOrg); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgReviewUsecase.ReviewOrganization(ctx, username, bidID, &entity.OrganizationReview{
+		PaymentDiscipline:   reviewOrg.PaymentDiscipline,
+		RequirementsClarity: reviewOrg.RequirementsClarity,
+		Fairness:            reviewOrg.Fairness,
+		Comment:             reviewOrg.Comment,
+	})
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetOrganizationReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgReviewUsecase.GetOrganizationReviews(ctx, orgID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) GetOrganizationRating(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.orgReviewUsecase.GetOrganizationRating(ctx, orgID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,8 @@
+package orgreview
+
+type ReviewOrganization struct {
+	PaymentDiscipline   int    `json:"paymentDiscipline" validate:"required,min=1,max=5"`
+	RequirementsClarity int    `json:"requirementsClarity" validate:"required,min=1,max=5"`
+	Fairness            int    `json:"fairness" validate:"required,min=1,max=5"`
+	Comment             string `json:"comment" validate:"max=1000"`
+}
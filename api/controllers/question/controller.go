@@ -0,0 +1,138 @@
+package question
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/entity"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	questionUsecase usecases.QuestionUsecase
+}
+
+func NewQuestionController(questionUsecase usecases.QuestionUsecase) *Controller {
+	return &Controller{
+		questionUsecase: questionUsecase,
+	}
+}
+
+func (c *Controller) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var askQuestion AskQuestion
+	if err := json.NewDecoder(r.Body).Decode(&askQuestion); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&askQuestion); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.questionUsecase.AskQuestion(ctx, username, tenderID, askQuestion.Question)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	c.getQuestions(w, r, c.questionUsecase.GetQuestions)
+}
+
+func (c *Controller) GetPendingQuestions(w http.ResponseWriter, r *http.Request) {
+	c.getQuestions(w, r, c.questionUsecase.GetPendingQuestions)
+}
+
+func (c *Controller) GetMyQuestions(w http.ResponseWriter, r *http.Request) {
+	c.getQuestions(w, r, c.questionUsecase.GetMyQuestions)
+}
+
+func (c *Controller) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	questionID, err := parsers.ParseVar(r, "questionId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var answerQuestion AnswerQuestion
+	if err := json.NewDecoder(r.Body).Decode(&answerQuestion); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&answerQuestion); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.questionUsecase.AnswerQuestion(ctx, username, questionID, answerQuestion.Answer)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+type getQuestionsFunc func(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.TenderQuestion], error)
+
+func (c *Controller) getQuestions(w http.ResponseWriter, r *http.Request, get getQuestionsFunc) {
+	ctx := r.Context()
+
+	tenderID, err := parsers.ParseVar(r, "tenderId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := get(ctx, username, tenderID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
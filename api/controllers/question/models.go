@@ -0,0 +1,9 @@
+package question
+
+type AskQuestion struct {
+	Question string `json:"question" validate:"required,max=1000"`
+}
+
+type AnswerQuestion struct {
+	Answer string `json:"answer" validate:"required,max=2000"`
+}
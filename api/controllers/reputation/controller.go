@@ -0,0 +1,54 @@
+package reputation
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"net/http"
+)
+
+type Controller struct {
+	reputationUsecase usecases.ReputationUsecase
+}
+
+func NewReputationController(reputationUsecase usecases.ReputationUsecase) *Controller {
+	return &Controller{
+		reputationUsecase: reputationUsecase,
+	}
+}
+
+func (c *Controller) GetUserReputation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseVar(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reputationUsecase.GetUserReputation(ctx, username)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetOrganizationReputation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reputationUsecase.GetOrganizationReputation(ctx, orgID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
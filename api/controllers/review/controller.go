@@ -0,0 +1,236 @@
+package review
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/entity"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	reviewUsecase usecases.ReviewUsecase
+}
+
+func NewReviewController(reviewUsecase usecases.ReviewUsecase) *Controller {
+	return &Controller{
+		reviewUsecase: reviewUsecase,
+	}
+}
+
+func (c *Controller) EditReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reviewID, err := parsers.ParseVar(r, "reviewId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var editReview EditReview
+	if err := json.NewDecoder(r.Body).Decode(&editReview); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&editReview); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reviewUsecase.EditReview(ctx, username, reviewID, editReview.Description, entity.ReviewRating{
+		Quality:       editReview.Quality,
+		Timeliness:    editReview.Timeliness,
+		Communication: editReview.Communication,
+	})
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reviewID, err := parsers.ParseVar(r, "reviewId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := c.reviewUsecase.DeleteReview(ctx, username, reviewID); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.Ok(w, http.StatusNoContent)
+}
+
+func (c *Controller) GetReviewHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reviewID, err := parsers.ParseVar(r, "reviewId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reviewUsecase.GetReviewHistory(ctx, username, reviewID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) ReplyReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reviewID, err := parsers.ParseVar(r, "reviewId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var replyReview ReplyReview
+	if err := json.NewDecoder(r.Body).Decode(&replyReview); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&replyReview); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reviewUsecase.ReplyReview(ctx, username, reviewID, replyReview.Reply)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) FlagReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reviewID, err := parsers.ParseVar(r, "reviewId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var flagReview FlagReview
+	if err := json.NewDecoder(r.Body).Decode(&flagReview); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&flagReview); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reviewUsecase.FlagReview(ctx, username, reviewID, flagReview.Reason)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reviewUsecase.GetModerationQueue(ctx, username, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reviewID, err := parsers.ParseVar(r, "reviewId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	decision, err := parsers.ParseQuery(r, "decision", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := validation.ValidateOneOf(entity.ReviewDecisionList, decision, "decision"); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.reviewUsecase.ModerateReview(ctx, username, reviewID, entity.ReviewDecision(decision))
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
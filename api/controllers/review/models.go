@@ -0,0 +1,16 @@
+package review
+
+type EditReview struct {
+	Description   string `json:"description" validate:"max=1000"`
+	Quality       *int   `json:"quality" validate:"omitempty,min=1,max=5"`
+	Timeliness    *int   `json:"timeliness" validate:"omitempty,min=1,max=5"`
+	Communication *int   `json:"communication" validate:"omitempty,min=1,max=5"`
+}
+
+type ReplyReview struct {
+	Reply string `json:"reply" validate:"required,max=1000"`
+}
+
+type FlagReview struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
@@ -61,13 +61,19 @@ func (c *Controller) GetTenders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := c.tenderUsecase.GetTenders(ctx, serviceTypes, pagination)
+	listQuery, err := parsers.ParseListQuery(r, parsers.TenderFields)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
 	}
 
-	responses.OkJSON(w, http.StatusOK, resp)
+	resp, err := c.tenderUsecase.GetTenders(ctx, serviceTypes, pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
 }
 
 func (c *Controller) GetMyTenders(w http.ResponseWriter, r *http.Request) {
@@ -87,13 +93,69 @@ func (c *Controller) GetMyTenders(w http.ResponseWriter, r *http.Request) {
 	// 	return
 	// }
 
-	resp, err := c.tenderUsecase.GetMyTenders(ctx, username, pagination)
+	listQuery, err := parsers.ParseListQuery(r, parsers.TenderFields)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
 	}
 
-	responses.OkJSON(w, http.StatusOK, resp)
+	resp, err := c.tenderUsecase.GetMyTenders(ctx, username, pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) ExportTenders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	format, err := parsers.ParseExportFormat(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	serviceTypes, err := parsers.ParseServiceTypes(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	listQuery, err := parsers.ParseListQuery(r, parsers.TenderFields)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	export := responses.NewExport(w, format, "tenders", exportColumns, exportRow)
+	export.Finish(c.tenderUsecase.ExportTenders(ctx, serviceTypes, listQuery, export.Write))
+}
+
+func (c *Controller) ExportMyTenders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	format, err := parsers.ParseExportFormat(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	listQuery, err := parsers.ParseListQuery(r, parsers.TenderFields)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	export := responses.NewExport(w, format, "my_tenders", exportColumns, exportRow)
+	export.Finish(c.tenderUsecase.ExportMyTenders(ctx, username, listQuery, export.Write))
 }
 
 func (c *Controller) GetTenderStatus(w http.ResponseWriter, r *http.Request) {
@@ -135,7 +197,7 @@ func (c *Controller) UpdateTenderStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -150,6 +212,35 @@ func (c *Controller) UpdateTenderStatus(w http.ResponseWriter, r *http.Request)
 	responses.OkJSON(w, http.StatusOK, resp)
 }
 
+func (c *Controller) UpdateTendersStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var batch BatchTenderStatus
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&batch); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.tenderUsecase.UpdateTendersStatus(ctx, username, batch.IDs, batch.Status, batch.Atomic)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkBatchJSON(w, resp)
+}
+
 func (c *Controller) PatchTender(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -159,7 +250,7 @@ func (c *Controller) PatchTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -196,7 +287,7 @@ func (c *Controller) RollbackTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username, err := parsers.ParseQuery(r, "username", true, parsers.ParserEmptyString)
+	username, err := parsers.ParseUsername(r)
 	if err != nil {
 		responses.ErrorHandler(w, err)
 		return
@@ -0,0 +1,29 @@
+package tender
+
+import (
+	"avito/api/responses"
+	"avito/internal/entity"
+	"strconv"
+)
+
+var exportColumns = []string{
+	"id", "name", "description", "status", "serviceType", "organizationId",
+	"version", "createdAt", "deadline", "sealed", "openedAt", "anonymous",
+}
+
+func exportRow(t *entity.Tender) []string {
+	return []string{
+		t.Id.String(),
+		t.Name,
+		t.Description,
+		string(t.Status),
+		string(t.ServiceType),
+		t.OrganizationID.String(),
+		strconv.Itoa(t.Version),
+		responses.CSVTime(&t.CreatedAt),
+		responses.CSVTime(t.Deadline),
+		strconv.FormatBool(t.Sealed),
+		responses.CSVTime(t.OpenedAt),
+		strconv.FormatBool(t.Anonymous),
+	}
+}
@@ -2,6 +2,7 @@ package tender
 
 import (
 	"avito/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +12,9 @@ type CreateTender struct {
 	Description     string                   `json:"description" validate:"required,max=500"`
 	ServiceType     entity.TenderServiceType `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
 	OrganizationID  uuid.UUID                `json:"organizationId" validate:"required,max=100,uuid4"`
+	Deadline        *time.Time               `json:"deadline"`
+	Sealed          bool                     `json:"sealed"`
+	Anonymous       bool                     `json:"anonymous"`
 	CreatorUserName string                   `json:"creatorUsername" validate:"required" copier:"-"`
 }
 
@@ -18,4 +22,11 @@ type PatchTender struct {
 	Name        string                   `json:"name" validate:"max=100"`
 	Description string                   `json:"description" validate:"max=500"`
 	ServiceType entity.TenderServiceType `json:"serviceType" validate:"omitempty,oneof=Construction Delivery Manufacture"`
+	Deadline    *time.Time               `json:"deadline"`
+}
+
+type BatchTenderStatus struct {
+	IDs    uuid.UUIDs              `json:"ids" validate:"required,min=1,max=100,unique"`
+	Status entity.TenderStatusType `json:"status" validate:"required,oneof=Created Published Closed"`
+	Atomic bool                    `json:"atomic"`
 }
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	userUsecase usecases.UserUsecase
+}
+
+func NewUserController(userUsecase usecases.UserUsecase) *Controller {
+	return &Controller{
+		userUsecase: userUsecase,
+	}
+}
+
+func (c *Controller) Register(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var registerUser RegisterUser
+	if err := json.NewDecoder(r.Body).Decode(&registerUser); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&registerUser); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	user := utils.MustTransformObj[RegisterUser, entity.User](&registerUser)
+
+	resp, err := c.userUsecase.Register(ctx, user, registerUser.Password)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseVar(r, "username", true, parsers.ParserEmptyString)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.userUsecase.GetUser(ctx, username)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.userUsecase.GetMe(ctx, username)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) PatchMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var patchUser PatchUser
+	if err := json.NewDecoder(r.Body).Decode(&patchUser); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(patchUser); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	patchUserEnt := utils.MustTransformObj[PatchUser, entity.User](&patchUser)
+
+	resp, err := c.userUsecase.PatchMe(ctx, username, patchUserEnt)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) DeactivateMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var deactivateUser DeactivateUser
+	if err := json.NewDecoder(r.Body).Decode(&deactivateUser); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := c.userUsecase.DeactivateMe(ctx, username, deactivateUser.Password); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.Ok(w, http.StatusNoContent)
+}
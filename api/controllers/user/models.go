@@ -0,0 +1,21 @@
+package user
+
+type RegisterUser struct {
+	Username  string `json:"username" validate:"required,max=50"`
+	Password  string `json:"password" validate:"required,min=8,max=72" copier:"-"`
+	FirstName string `json:"firstName" validate:"max=50"`
+	LastName  string `json:"lastName" validate:"max=50"`
+	Email     string `json:"email" validate:"omitempty,email,max=255"`
+	Locale    string `json:"locale" validate:"omitempty,oneof=ru en"`
+}
+
+type PatchUser struct {
+	FirstName string `json:"firstName" validate:"max=50"`
+	LastName  string `json:"lastName" validate:"max=50"`
+	Email     string `json:"email" validate:"omitempty,email,max=255"`
+	Locale    string `json:"locale" validate:"omitempty,oneof=ru en"`
+}
+
+type DeactivateUser struct {
+	Password string `json:"password"`
+}
@@ -0,0 +1,185 @@
+package webhook
+
+import (
+	"avito/api/parsers"
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/api/validation"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"encoding/json"
+	"net/http"
+)
+
+type Controller struct {
+	webhookUsecase usecases.WebhookUsecase
+}
+
+func NewWebhookController(webhookUsecase usecases.WebhookUsecase) *Controller {
+	return &Controller{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+func (c *Controller) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	var createWebhook CreateWebhook
+	if err := json.NewDecoder(r.Body).Decode(&createWebhook); err != nil {
+		responses.ErrorHandler(w, validation.ErrParsed)
+		return
+	}
+
+	if err := validation.ValidateStruct(&createWebhook); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	for _, event := range createWebhook.Events {
+		if err := validation.ValidateOneOf(entity.EventTypeList, event, "events"); err != nil {
+			responses.ErrorHandler(w, err)
+			return
+		}
+	}
+
+	webhook := utils.MustTransformObj[CreateWebhook, entity.Webhook](&createWebhook)
+
+	resp, err := c.webhookUsecase.CreateWebhook(ctx, username, orgID, webhook)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
+
+func (c *Controller) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := parsers.ParseVar(r, "organizationId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.webhookUsecase.GetWebhooks(ctx, username, orgID, pagination)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID, err := parsers.ParseVar(r, "webhookId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	if err := c.webhookUsecase.DeleteWebhook(ctx, username, webhookID); err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.Ok(w, http.StatusNoContent)
+}
+
+func (c *Controller) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID, err := parsers.ParseVar(r, "webhookId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	pagination, err := parsers.ParsePagination(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	listQuery, err := parsers.ParseListQuery(r, parsers.DeliveryFields)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.webhookUsecase.GetDeliveries(ctx, username, webhookID, pagination, listQuery)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkPageJSON(w, r, http.StatusOK, resp, pagination)
+}
+
+func (c *Controller) Redeliver(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID, err := parsers.ParseVar(r, "webhookId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	deliveryID, err := parsers.ParseVar(r, "deliveryId", true, parsers.ParserUUID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	username, err := parsers.ParseUsername(r)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	resp, err := c.webhookUsecase.Redeliver(ctx, username, webhookID, deliveryID)
+	if err != nil {
+		responses.ErrorHandler(w, err)
+		return
+	}
+
+	responses.OkJSON(w, http.StatusOK, resp)
+}
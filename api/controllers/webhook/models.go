@@ -0,0 +1,7 @@
+package webhook
+
+type CreateWebhook struct {
+	URL    string   `json:"url" validate:"required,url,startswith=https://,max=500"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Secret string   `json:"secret" validate:"required,min=16,max=100"`
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"avito/api/responses"
+	"avito/api/usecases"
+	"avito/internal/authz"
+	"avito/internal/entity"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const apiKeyScheme = "ApiKey "
+
+// ApiKeyAuth authenticate requests with "Authorization: ApiKey <key>" header,
+// requests without header are passed as is and identified by username
+func ApiKeyAuth(apiKeyUsecase usecases.ApiKeyUsecase) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			secret, ok := strings.CutPrefix(header, apiKeyScheme)
+			if !ok {
+				responses.ErrorHandler(w, entity.ErrInvalidApiKey)
+				return
+			}
+
+			key, err := apiKeyUsecase.Authenticate(r.Context(), strings.TrimSpace(secret))
+			if err != nil {
+				responses.ErrorHandler(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(authz.WithApiKey(r.Context(), key)))
+		})
+	}
+}
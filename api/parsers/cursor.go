@@ -0,0 +1,98 @@
+package parsers
+
+import (
+	"avito/internal/entity"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var cursorSecret = func() []byte {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return secret
+}()
+
+// SetCursorSecret set key for cursor signing, with empty secret random key is kept,
+// so cursors will not survive restart and will not be valid on other instances
+func SetCursorSecret(secret string) {
+	if secret != "" {
+		cursorSecret = []byte(secret)
+	}
+}
+
+type cursorSortToken struct {
+	Field string `json:"f"`
+	Desc  bool   `json:"d,omitempty"`
+}
+
+type cursorToken struct {
+	Sort   []cursorSortToken `json:"s"`
+	Values []any             `json:"v"`
+	ID     uuid.UUID         `json:"id"`
+}
+
+func signCursor(payload []byte) []byte {
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func EncodeCursor(cursor *entity.Cursor) string {
+	token := cursorToken{Values: cursor.Values, ID: cursor.ID}
+	for _, s := range cursor.Sort {
+		token.Sort = append(token.Sort, cursorSortToken{Field: s.Field, Desc: s.Desc})
+	}
+
+	payload, _ := json.Marshal(token)
+	enc := base64.RawURLEncoding
+
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(signCursor(payload))
+}
+
+func DecodeCursor(s string) (*entity.Cursor, error) {
+	enc := base64.RawURLEncoding
+
+	payloadStr, signStr, ok := strings.Cut(s, ".")
+	if !ok {
+		return nil, errors.New("invalid cursor format")
+	}
+
+	payload, err := enc.DecodeString(payloadStr)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := enc.DecodeString(signStr)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sign, signCursor(payload)) {
+		return nil, errors.New("invalid cursor sign")
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return nil, err
+	}
+
+	cursor := entity.Cursor{ID: token.ID}
+	for _, s := range token.Sort {
+		cursor.Sort = append(cursor.Sort, entity.SortField{Field: s.Field, Desc: s.Desc})
+	}
+	for _, v := range token.Values {
+		// json numbers are float64, but sort keys are ints
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			v = int64(f)
+		}
+		cursor.Values = append(cursor.Values, v)
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,38 @@
+package parsers
+
+import (
+	"avito/api/validation"
+	"avito/internal/entity"
+	"net/http"
+	"strconv"
+)
+
+func ParseEventTypes(r *http.Request) ([]entity.EventType, error) {
+	var eventTypes []entity.EventType
+	for _, s := range r.URL.Query()["event_type"] {
+		if err := validation.ValidateOneOf(entity.EventTypeList, s, "event_type"); err != nil {
+			return nil, err
+		}
+		eventTypes = append(eventTypes, entity.EventType(s))
+	}
+
+	return eventTypes, nil
+}
+
+// ParseLastEventID parse stream resume point, browsers send it in header on reconnect
+func ParseLastEventID(r *http.Request) (*int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("lastEventId")
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return nil, validation.NewValidateError("invalid last event id format")
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,20 @@
+package parsers
+
+import (
+	"avito/api/validation"
+	"avito/internal/entity"
+	"net/http"
+)
+
+// ParseExportFormat parse required format of list export
+func ParseExportFormat(r *http.Request) (entity.ExportFormat, error) {
+	format, err := ParseQuery(r, "format", true, ParserEmptyString)
+	if err != nil {
+		return "", err
+	}
+	if err := validation.ValidateOneOf(entity.ExportFormatList, format, "format"); err != nil {
+		return "", err
+	}
+
+	return entity.ExportFormat(format), nil
+}
@@ -0,0 +1,205 @@
+package parsers
+
+import (
+	"avito/api/validation"
+	"avito/internal/entity"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type FieldSpec struct {
+	Column string
+	Parser func(string) (any, error)
+}
+
+// FieldsWhitelist maps api field name to db column, only these fields can be used in filter and sort.
+type FieldsWhitelist map[string]FieldSpec
+
+var (
+	FieldString = func(s string) (any, error) { return s, nil }
+
+	FieldInt = func(s string) (any, error) { return ParserInt(s) }
+
+	FieldTime = func(s string) (any, error) {
+		for _, layout := range []string{time.RFC3339, time.DateOnly} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid time: %s", s)
+	}
+)
+
+func FieldOneOf[T ~string](list []T) func(string) (any, error) {
+	return func(s string) (any, error) {
+		if err := validation.ValidateOneOf(list, s, "value"); err != nil {
+			return nil, err
+		}
+		return T(s), nil
+	}
+}
+
+var TenderFields = FieldsWhitelist{
+	"name":           {Column: "name", Parser: FieldString},
+	"status":         {Column: "status", Parser: FieldOneOf(entity.TenderStatusTypeList)},
+	"serviceType":    {Column: "service_type", Parser: FieldOneOf(entity.TenderServiceTypeList)},
+	"organizationId": {Column: "organization_id", Parser: func(s string) (any, error) { return ParserUUID(s) }},
+	"version":        {Column: "version", Parser: FieldInt},
+	"createdAt":      {Column: "created_at", Parser: FieldTime},
+}
+
+var BidFields = FieldsWhitelist{
+	"name":       {Column: "name", Parser: FieldString},
+	"status":     {Column: "status", Parser: FieldOneOf(entity.BidStatusTypeList)},
+	"authorType": {Column: "author_type", Parser: FieldOneOf(entity.BidAuthorTypeList)},
+	"authorId":   {Column: "author_id", Parser: func(s string) (any, error) { return ParserUUID(s) }},
+	"version":    {Column: "version", Parser: FieldInt},
+	"createdAt":  {Column: "created_at", Parser: FieldTime},
+}
+
+var OrganizationFields = FieldsWhitelist{
+	"name":      {Column: "name", Parser: FieldString},
+	"type":      {Column: "type", Parser: FieldOneOf(entity.OrganizationTypeList)},
+	"createdAt": {Column: "created_at", Parser: FieldTime},
+	"updatedAt": {Column: "updated_at", Parser: FieldTime},
+}
+
+var ReviewFields = FieldsWhitelist{
+	"description":   {Column: "description", Parser: FieldString},
+	"quality":       {Column: "quality", Parser: FieldInt},
+	"timeliness":    {Column: "timeliness", Parser: FieldInt},
+	"communication": {Column: "communication", Parser: FieldInt},
+	"createdAt":     {Column: "created_at", Parser: FieldTime},
+}
+
+var DeliveryFields = FieldsWhitelist{
+	"status":    {Column: "status", Parser: FieldOneOf(entity.DeliveryStatusTypeList)},
+	"eventType": {Column: "event_type", Parser: FieldOneOf(entity.EventTypeList)},
+	"attempts":  {Column: "attempts", Parser: FieldInt},
+	"createdAt": {Column: "created_at", Parser: FieldTime},
+}
+
+// ParseListQuery parse filter and sort query params.
+// filter: conditions joined by ';', e.g. status==Published;createdAt>=2026-01-01;serviceType=in=(Delivery,Construction)
+// sort: fields joined by ',', '-' prefix means desc, e.g. -createdAt,name
+func ParseListQuery(r *http.Request, fields FieldsWhitelist) (*entity.ListQuery, error) {
+	query := entity.ListQuery{}
+
+	filter, err := rawFilter(r)
+	if err != nil {
+		return nil, err
+	}
+	if filter != "" {
+		for _, expr := range strings.Split(filter, ";") {
+			cond, err := parseFilterCond(expr, fields)
+			if err != nil {
+				return nil, err
+			}
+			query.Filters = append(query.Filters, *cond)
+		}
+	}
+
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		for _, field := range strings.Split(sort, ",") {
+			desc := strings.HasPrefix(field, "-")
+			field = strings.TrimPrefix(field, "-")
+
+			spec, ok := fields[field]
+			if !ok {
+				return nil, validation.NewValidateError(fmt.Sprintf("unknown sort field: %s", field))
+			}
+			query.Sort = append(query.Sort, entity.SortField{Field: spec.Column, Desc: desc})
+		}
+	}
+
+	return &query, nil
+}
+
+// ListQueryValues is r.URL.Query() with filter kept, e.g. to build links to other pages of same list
+func ListQueryValues(r *http.Request) url.Values {
+	query := r.URL.Query()
+	if filter, err := rawFilter(r); err == nil && filter != "" {
+		query.Set("filter", filter)
+	}
+	return query
+}
+
+// rawFilter read filter from raw query, net/url drops query pairs with unescaped ';',
+// so filter is taken as is and any other such pair is rejected instead of silently lost
+func rawFilter(r *http.Request) (string, error) {
+	filter := ""
+	for _, pair := range strings.Split(r.URL.RawQuery, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if key != "filter" {
+			if strings.Contains(pair, ";") {
+				return "", validation.NewValidateError(fmt.Sprintf("unescaped ';' in query param: %s", key))
+			}
+			continue
+		}
+
+		unescaped, err := url.QueryUnescape(value)
+		if err != nil {
+			return "", validation.NewValidateError("invalid filter format")
+		}
+		if filter != "" {
+			return "", validation.NewValidateError("filter must be single, join conditions by ';'")
+		}
+		filter = unescaped
+	}
+
+	return filter, nil
+}
+
+func parseFilterCond(expr string, fields FieldsWhitelist) (*entity.FilterCond, error) {
+	nameEnd := strings.IndexFunc(expr, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if nameEnd <= 0 {
+		return nil, validation.NewValidateError(fmt.Sprintf("invalid filter expression: %s", expr))
+	}
+
+	name := expr[:nameEnd]
+	spec, ok := fields[name]
+	if !ok {
+		return nil, validation.NewValidateError(fmt.Sprintf("unknown filter field: %s", name))
+	}
+
+	rest := expr[nameEnd:]
+	var op entity.FilterOperator
+	for _, o := range entity.FilterOperatorList {
+		if strings.HasPrefix(rest, string(o)) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return nil, validation.NewValidateError(fmt.Sprintf("invalid filter operator: %s", expr))
+	}
+
+	rawValue := strings.TrimPrefix(rest, string(op))
+	rawValues := []string{rawValue}
+	if op == entity.OpIn || op == entity.OpNotIn {
+		if !strings.HasPrefix(rawValue, "(") || !strings.HasSuffix(rawValue, ")") {
+			return nil, validation.NewValidateError(fmt.Sprintf("list value must be in brackets: %s", expr))
+		}
+		rawValues = strings.Split(rawValue[1:len(rawValue)-1], ",")
+	}
+
+	cond := entity.FilterCond{Field: spec.Column, Op: op}
+	for _, v := range rawValues {
+		if v == "" {
+			return nil, validation.NewValidateError(fmt.Sprintf("empty filter value: %s", expr))
+		}
+
+		value, err := spec.Parser(v)
+		if err != nil {
+			return nil, validation.NewValidateError(fmt.Sprintf("invalid %s value: %s", name, v))
+		}
+		cond.Values = append(cond.Values, value)
+	}
+
+	return &cond, nil
+}
@@ -26,7 +26,19 @@ func ParsePagination(r *http.Request) (*entity.Pagination, error) {
 		params.Limit = parsedLimit
 	}
 
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		parsedCursor, err := DecodeCursor(cursor)
+		if err != nil {
+			return nil, validation.NewValidateError("invalid cursor")
+		}
+		params.Cursor = parsedCursor
+	}
+
 	if offset := r.URL.Query().Get("offset"); offset != "" {
+		if params.Cursor != nil {
+			return nil, validation.NewValidateError("offset cant be used with cursor")
+		}
+
 		parsedOffset, err := strconv.Atoi(offset)
 		if err != nil || parsedOffset < 0 {
 			return nil, validation.NewValidateError("offset must be simple positive num")
@@ -34,5 +46,13 @@ func ParsePagination(r *http.Request) (*entity.Pagination, error) {
 		params.Offset = parsedOffset
 	}
 
+	if withTotal := r.URL.Query().Get("withTotal"); withTotal != "" {
+		parsedWithTotal, err := strconv.ParseBool(withTotal)
+		if err != nil {
+			return nil, validation.NewValidateError("withTotal must be bool")
+		}
+		params.WithTotal = parsedWithTotal
+	}
+
 	return &params, nil
 }
@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"avito/api/validation"
+	"avito/internal/authz"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -28,6 +29,10 @@ var (
 		}
 		return parse, nil
 	}
+
+	ParserBool = func(s string) (bool, error) {
+		return strconv.ParseBool(s)
+	}
 )
 
 func parse[T any](value string, parseName string, requiredFlag bool, parser func(string) (T, error)) (T, error) {
@@ -57,3 +62,12 @@ func ParseQuery[T any](r *http.Request, parseName string, requiredFlag bool, par
 	paramStr := r.URL.Query().Get(parseName)
 	return parse(paramStr, parseName, requiredFlag, parser)
 }
+
+// ParseUsername parse required username, requests authenticated by api key act as the key and dont need it
+func ParseUsername(r *http.Request) (string, error) {
+	if authz.ApiKeyFromContext(r.Context()) != nil {
+		return "", nil
+	}
+
+	return ParseQuery(r, "username", true, ParserEmptyString)
+}
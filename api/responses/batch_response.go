@@ -0,0 +1,43 @@
+package responses
+
+import (
+	"avito/internal/entity"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type BatchItemAnswer struct {
+	ID     uuid.UUID `json:"id"`
+	Status int       `json:"status"`
+	Reason string    `json:"reason,omitempty"`
+	Item   any       `json:"item,omitempty"`
+}
+
+type BatchAnswer struct {
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemAnswer `json:"results"`
+}
+
+// OkBatchJSON write results of batch in request order, item status is the one single request would get
+func OkBatchJSON[T any](w http.ResponseWriter, items []entity.BatchItem[T]) {
+	answer := BatchAnswer{Results: []BatchItemAnswer{}}
+	for _, item := range items {
+		if item.Err == nil {
+			answer.Succeeded++
+			answer.Results = append(answer.Results, BatchItemAnswer{ID: item.ID, Status: http.StatusOK, Item: item.Item})
+			continue
+		}
+
+		answer.Failed++
+		status, reason := ErrorStatus(item.Err)
+		result := BatchItemAnswer{ID: item.ID, Status: status}
+		if reason != nil {
+			result.Reason = reason.Error()
+		}
+		answer.Results = append(answer.Results, result)
+	}
+
+	OkJSON(w, http.StatusOK, answer)
+}
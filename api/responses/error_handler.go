@@ -8,64 +8,227 @@ import (
 )
 
 func ErrorHandler(w http.ResponseWriter, err error) {
+	status, reason := ErrorStatus(err)
+	if reason == nil {
+		Error(w, status)
+		return
+	}
+	ErrorJSON(w, status, reason)
+}
+
+// ErrorStatus map error to response status and reason shown to client, unknown errors have no reason
+func ErrorStatus(err error) (int, error) {
 	var validateErr *validation.ValidateError
 
 	switch {
 	case errors.Is(err, entity.ErrUserNotFound):
-		ErrorJSON(w, http.StatusUnauthorized, entity.ErrUserNotFound)
+		return http.StatusUnauthorized, entity.ErrUserNotFound
 
 	case errors.Is(err, entity.ErrOrgNotFound):
-		ErrorJSON(w, http.StatusNotFound, entity.ErrOrgNotFound)
+		return http.StatusNotFound, entity.ErrOrgNotFound
 
 	case errors.Is(err, entity.ErrTenderNotFound):
-		ErrorJSON(w, http.StatusNotFound, entity.ErrTenderNotFound)
+		return http.StatusNotFound, entity.ErrTenderNotFound
 
 	case errors.Is(err, entity.ErrBidNotFound):
-		ErrorJSON(w, http.StatusNotFound, entity.ErrBidNotFound)
+		return http.StatusNotFound, entity.ErrBidNotFound
+
+	case errors.Is(err, entity.ErrInvitationNotFound):
+		return http.StatusNotFound, entity.ErrInvitationNotFound
+
+	case errors.Is(err, entity.ErrApiKeyNotFound):
+		return http.StatusNotFound, entity.ErrApiKeyNotFound
+
+	case errors.Is(err, entity.ErrWebhookNotFound):
+		return http.StatusNotFound, entity.ErrWebhookNotFound
+
+	case errors.Is(err, entity.ErrDeliveryNotFound):
+		return http.StatusNotFound, entity.ErrDeliveryNotFound
+
+	case errors.Is(err, entity.ErrNotificationNotFound):
+		return http.StatusNotFound, entity.ErrNotificationNotFound
+
+	case errors.Is(err, entity.ErrAttachmentNotFound):
+		return http.StatusNotFound, entity.ErrAttachmentNotFound
+
+	case errors.Is(err, entity.ErrQuestionNotFound):
+		return http.StatusNotFound, entity.ErrQuestionNotFound
+
+	case errors.Is(err, entity.ErrReviewNotFound):
+		return http.StatusNotFound, entity.ErrReviewNotFound
+
+	case errors.Is(err, entity.ErrOpeningNotFound):
+		return http.StatusNotFound, entity.ErrOpeningNotFound
 
 	case errors.Is(err, entity.ErrTenderVersionNotFound):
-		ErrorJSON(w, http.StatusNotFound, entity.ErrTenderVersionNotFound)
+		return http.StatusNotFound, entity.ErrTenderVersionNotFound
 
 	case errors.Is(err, entity.ErrBidVersionNotFound):
-		ErrorJSON(w, http.StatusNotFound, entity.ErrBidVersionNotFound)
+		return http.StatusNotFound, entity.ErrBidVersionNotFound
 
 	case errors.Is(err, entity.ErrUserNotSpecified):
-		ErrorJSON(w, http.StatusUnauthorized, entity.ErrUserNotSpecified)
+		return http.StatusUnauthorized, entity.ErrUserNotSpecified
+
+	case errors.Is(err, entity.ErrUserDeactivated):
+		return http.StatusForbidden, entity.ErrUserDeactivated
+
+	case errors.Is(err, entity.ErrInvalidPassword):
+		return http.StatusUnauthorized, entity.ErrInvalidPassword
+
+	case errors.Is(err, entity.ErrInvalidApiKey):
+		return http.StatusUnauthorized, entity.ErrInvalidApiKey
+
+	case errors.Is(err, entity.ErrUsernameTaken):
+		return http.StatusConflict, entity.ErrUsernameTaken
 
 	case errors.Is(err, entity.ErrUserPermissionTender):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrUserPermissionTender)
+		return http.StatusForbidden, entity.ErrUserPermissionTender
 
 	case errors.Is(err, entity.ErrCreateBidTender):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrCreateBidTender)
+		return http.StatusForbidden, entity.ErrCreateBidTender
+
+	case errors.Is(err, entity.ErrTenderDeadlinePassed):
+		return http.StatusForbidden, entity.ErrTenderDeadlinePassed
+
+	case errors.Is(err, entity.ErrQuestionTender):
+		return http.StatusForbidden, entity.ErrQuestionTender
+
+	case errors.Is(err, entity.ErrQuestionsClosed):
+		return http.StatusForbidden, entity.ErrQuestionsClosed
 
 	case errors.Is(err, entity.ErrUserPermissionBid):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrUserPermissionBid)
+		return http.StatusForbidden, entity.ErrUserPermissionBid
 
 	case errors.Is(err, entity.ErrUserPermissionBidsTender):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrUserPermissionBidsTender)
+		return http.StatusForbidden, entity.ErrUserPermissionBidsTender
+
+	case errors.Is(err, entity.ErrBidsSealed):
+		return http.StatusForbidden, entity.ErrBidsSealed
+
+	case errors.Is(err, entity.ErrBiddersHidden):
+		return http.StatusForbidden, entity.ErrBiddersHidden
 
 	case errors.Is(err, entity.ErrUserPermissionCreateTender):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrUserPermissionCreateTender)
+		return http.StatusForbidden, entity.ErrUserPermissionCreateTender
 
 	case errors.Is(err, entity.ErrUserPermissionShipBid):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrUserPermissionShipBid)
+		return http.StatusForbidden, entity.ErrUserPermissionShipBid
 
 	case errors.Is(err, entity.ErrFeedbackPermission):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrFeedbackPermission)
+		return http.StatusForbidden, entity.ErrFeedbackPermission
 
 	case errors.Is(err, entity.ErrUserPermissionRewiew):
-		ErrorJSON(w, http.StatusForbidden, entity.ErrUserPermissionRewiew)
+		return http.StatusForbidden, entity.ErrUserPermissionRewiew
+
+	case errors.Is(err, entity.ErrReviewExists):
+		return http.StatusConflict, entity.ErrReviewExists
+
+	case errors.Is(err, entity.ErrOrgReviewExists):
+		return http.StatusConflict, entity.ErrOrgReviewExists
+
+	case errors.Is(err, entity.ErrBidNotDecided):
+		return http.StatusForbidden, entity.ErrBidNotDecided
+
+	case errors.Is(err, entity.ErrUserPermissionReview):
+		return http.StatusForbidden, entity.ErrUserPermissionReview
+
+	case errors.Is(err, entity.ErrReviewEditWindow):
+		return http.StatusForbidden, entity.ErrReviewEditWindow
+
+	case errors.Is(err, entity.ErrReviewReplied):
+		return http.StatusConflict, entity.ErrReviewReplied
+
+	case errors.Is(err, entity.ErrReviewFlagged):
+		return http.StatusConflict, entity.ErrReviewFlagged
+
+	case errors.Is(err, entity.ErrReviewNotFlagged):
+		return http.StatusConflict, entity.ErrReviewNotFlagged
+
+	case errors.Is(err, entity.ErrUserPermissionOrg):
+		return http.StatusForbidden, entity.ErrUserPermissionOrg
+
+	case errors.Is(err, entity.ErrUserPermissionInvitation):
+		return http.StatusForbidden, entity.ErrUserPermissionInvitation
+
+	case errors.Is(err, entity.ErrInvitationNotPending):
+		return http.StatusConflict, entity.ErrInvitationNotPending
+
+	case errors.Is(err, entity.ErrInvitationExists):
+		return http.StatusConflict, entity.ErrInvitationExists
+
+	case errors.Is(err, entity.ErrAlreadyResponsible):
+		return http.StatusConflict, entity.ErrAlreadyResponsible
+
+	case errors.Is(err, entity.ErrNotResponsible):
+		return http.StatusNotFound, entity.ErrNotResponsible
+
+	case errors.Is(err, entity.ErrLastResponsible):
+		return http.StatusConflict, entity.ErrLastResponsible
+
+	case errors.Is(err, entity.ErrLastOwner):
+		return http.StatusConflict, entity.ErrLastOwner
+
+	case errors.Is(err, entity.ErrBidCanceled):
+		return http.StatusConflict, entity.ErrBidCanceled
+
+	case errors.Is(err, entity.ErrBidPublished):
+		return http.StatusConflict, entity.ErrBidPublished
+
+	case errors.Is(err, entity.ErrBidNotWithdrawable):
+		return http.StatusConflict, entity.ErrBidNotWithdrawable
+
+	case errors.Is(err, entity.ErrBidNotWithdrawn):
+		return http.StatusConflict, entity.ErrBidNotWithdrawn
+
+	case errors.Is(err, entity.ErrTenderNotSealed):
+		return http.StatusConflict, entity.ErrTenderNotSealed
+
+	case errors.Is(err, entity.ErrTenderOpened):
+		return http.StatusConflict, entity.ErrTenderOpened
+
+	case errors.Is(err, entity.ErrDeadlineEarlier):
+		return http.StatusConflict, entity.ErrDeadlineEarlier
+
+	case errors.Is(err, entity.ErrBatchRolledBack):
+		return http.StatusFailedDependency, entity.ErrBatchRolledBack
+
+	case errors.Is(err, entity.ErrResubmitBidTender):
+		return http.StatusForbidden, entity.ErrResubmitBidTender
+
+	case errors.Is(err, entity.ErrResubmitLimit):
+		return http.StatusForbidden, entity.ErrResubmitLimit
+
+	case errors.Is(err, entity.ErrApiKeyRevoked):
+		return http.StatusConflict, entity.ErrApiKeyRevoked
+
+	case errors.Is(err, entity.ErrAttachmentEmpty):
+		return http.StatusBadRequest, entity.ErrAttachmentEmpty
+
+	case errors.Is(err, entity.ErrAttachmentLimit):
+		return http.StatusBadRequest, entity.ErrAttachmentLimit
+
+	case errors.Is(err, entity.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge, entity.ErrAttachmentTooLarge
+
+	case errors.Is(err, entity.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType, entity.ErrAttachmentType
 
 	case errors.Is(err, entity.ErrShipBidTender):
-		ErrorJSON(w, http.StatusBadRequest, entity.ErrShipBidTender)
+		return http.StatusBadRequest, entity.ErrShipBidTender
+
+	case errors.Is(err, entity.ErrDeadlinePast):
+		return http.StatusBadRequest, entity.ErrDeadlinePast
+
+	case errors.Is(err, entity.ErrInvalidCursor):
+		return http.StatusBadRequest, entity.ErrInvalidCursor
 
 	case errors.Is(err, validation.ErrParsed):
-		ErrorJSON(w, http.StatusBadRequest, validation.ErrParsed)
+		return http.StatusBadRequest, validation.ErrParsed
 
 	case errors.As(err, &validateErr):
-		ErrorJSON(w, http.StatusBadRequest, validateErr)
+		return http.StatusBadRequest, validateErr
 
 	default:
-		Error(w, http.StatusInternalServerError)
+		return http.StatusInternalServerError, nil
 	}
 }
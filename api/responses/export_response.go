@@ -0,0 +1,131 @@
+package responses
+
+import (
+	"avito/internal/entity"
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// rows are pushed to client by portions
+const exportFlushRows = 500
+
+// Export write list rows to response as they are read, in csv or ndjson.
+// Response starts with first row, so error before it is answered as usual, later one cuts response
+type Export[T any] struct {
+	w        http.ResponseWriter
+	format   entity.ExportFormat
+	filename string
+	columns  []string
+	row      func(*T) []string
+
+	csv     *csv.Writer
+	json    *json.Encoder
+	rows    int
+	started bool
+}
+
+// NewExport create export of list, columns and row give csv header and record of item, ndjson lines are items json
+func NewExport[T any](w http.ResponseWriter, format entity.ExportFormat, name string, columns []string, row func(*T) []string) *Export[T] {
+	return &Export[T]{
+		w:        w,
+		format:   format,
+		filename: name + "." + string(format),
+		columns:  columns,
+		row:      row,
+	}
+}
+
+func (e *Export[T]) Write(item *T) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	var err error
+	if e.format == entity.ExportCSV {
+		err = e.csv.Write(e.row(item))
+	} else {
+		err = e.json.Encode(item)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// Finish complete export with its result, error is answered only if no row was written
+func (e *Export[T]) Finish(err error) {
+	if err != nil {
+		if !e.started {
+			ErrorHandler(e.w, err)
+			return
+		}
+		log.Printf("export %s cut after %d rows: %v", e.filename, e.rows, err)
+		return
+	}
+
+	if err := e.start(); err != nil {
+		return
+	}
+	e.flush()
+}
+
+func (e *Export[T]) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	if e.format == entity.ExportCSV {
+		e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		e.w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	e.w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": e.filename}))
+	e.w.WriteHeader(http.StatusOK)
+
+	if e.format == entity.ExportCSV {
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(e.columns)
+	}
+	e.json = json.NewEncoder(e.w)
+	return nil
+}
+
+func (e *Export[T]) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if flusher, ok := e.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// CSVTime format optional time for csv record, absent time is empty
+func CSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// CSVInt format optional number for csv record, absent number is empty
+func CSVInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
@@ -11,3 +11,7 @@ func OkJSON(w http.ResponseWriter, statusCode int, resp any) {
 
 	json.NewEncoder(w).Encode(resp)
 }
+
+func Ok(w http.ResponseWriter, statusCode int) {
+	w.WriteHeader(statusCode)
+}
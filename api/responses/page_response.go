@@ -0,0 +1,105 @@
+package responses
+
+import (
+	"avito/api/parsers"
+	"avito/internal/entity"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	NextCursorHeader = "X-Next-Cursor"
+	TotalCountHeader = "X-Total-Count"
+	LinkHeader       = "Link"
+)
+
+type PageMeta struct {
+	Limit      int     `json:"limit"`
+	Offset     *int    `json:"offset,omitempty"`
+	Total      *int64  `json:"total,omitempty"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+type PageEnvelope[T any] struct {
+	Items      []T      `json:"items"`
+	Pagination PageMeta `json:"pagination"`
+}
+
+// OkPageJSON write page items as json array with pagination headers,
+// or as envelope with items and pagination if requested by envelope=true.
+// Cursor clients need nextCursor in body, so envelope is default when cursor is passed, empty one starts from first page
+func OkPageJSON[T any](w http.ResponseWriter, r *http.Request, statusCode int, page *entity.Page[T], pag *entity.Pagination) {
+	meta := PageMeta{Limit: pag.Limit, Total: page.Total}
+	if pag.Cursor == nil {
+		meta.Offset = &pag.Offset
+	}
+	if page.NextCursor != nil {
+		nextCursor := parsers.EncodeCursor(page.NextCursor)
+		meta.NextCursor = &nextCursor
+		w.Header().Set(NextCursorHeader, nextCursor)
+	}
+	if page.Total != nil {
+		w.Header().Set(TotalCountHeader, strconv.FormatInt(*page.Total, 10))
+	}
+	if links := pageLinks(r, meta); links != "" {
+		w.Header().Set(LinkHeader, links)
+	}
+
+	query := parsers.ListQueryValues(r)
+	envelope := query.Has("cursor")
+	if query.Has("envelope") {
+		envelope, _ = strconv.ParseBool(query.Get("envelope"))
+	}
+	if envelope {
+		items := page.Items
+		if items == nil {
+			items = []T{}
+		}
+		OkJSON(w, statusCode, PageEnvelope[T]{Items: items, Pagination: meta})
+		return
+	}
+
+	OkJSON(w, statusCode, page.Items)
+}
+
+// pageLinks build RFC 8288 links, prev and last are available only for offset pagination
+func pageLinks(r *http.Request, meta PageMeta) string {
+	// filter is escaped by Encode, so links keep it even when it was sent with raw ';'
+	link := func(rel string, set map[string]string) string {
+		query := parsers.ListQueryValues(r)
+		query.Del("offset")
+		query.Del("cursor")
+		for k, v := range set {
+			query.Set(k, v)
+		}
+		return fmt.Sprintf("<%s?%s>; rel=\"%s\"", r.URL.Path, query.Encode(), rel)
+	}
+
+	first := map[string]string{}
+	if parsers.ListQueryValues(r).Has("cursor") {
+		// first page of cursor pagination keeps envelope response
+		first["cursor"] = ""
+	}
+	links := []string{link("first", first)}
+
+	if meta.NextCursor != nil {
+		links = append(links, link("next", map[string]string{"cursor": *meta.NextCursor}))
+	}
+
+	if meta.Offset != nil && *meta.Offset > 0 {
+		prevOffset := max(0, *meta.Offset-meta.Limit)
+		links = append(links, link("prev", map[string]string{"offset": strconv.Itoa(prevOffset)}))
+	}
+
+	if meta.Offset != nil && meta.Total != nil && meta.Limit > 0 {
+		lastOffset := 0
+		if *meta.Total > 0 {
+			lastOffset = int((*meta.Total-1)/int64(meta.Limit)) * meta.Limit
+		}
+		links = append(links, link("last", map[string]string{"offset": strconv.Itoa(lastOffset)}))
+	}
+
+	return strings.Join(links, ", ")
+}
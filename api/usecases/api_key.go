@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ApiKeyUsecase interface {
+	CreateApiKey(ctx context.Context, username string, orgID uuid.UUID, name string, permissions []string) (*entity.IssuedApiKey, error)
+	GetApiKeys(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.ApiKey], error)
+	RevokeApiKey(ctx context.Context, username string, keyID uuid.UUID) (*entity.ApiKey, error)
+
+	Authenticate(ctx context.Context, secret string) (*entity.ApiKey, error)
+}
@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentUsecase interface {
+	AddTenderAttachment(ctx context.Context, username string, tenderID uuid.UUID, upload *entity.Upload) (*entity.Attachment, error)
+	GetTenderAttachments(ctx context.Context, username string, tenderID uuid.UUID) ([]entity.Attachment, error)
+	GetTenderAttachment(ctx context.Context, username string, tenderID uuid.UUID, attachmentID uuid.UUID) (*entity.Attachment, io.ReadCloser, error)
+	RemoveTenderAttachment(ctx context.Context, username string, tenderID uuid.UUID, attachmentID uuid.UUID) error
+
+	AddBidAttachment(ctx context.Context, username string, bidID uuid.UUID, upload *entity.Upload) (*entity.Attachment, error)
+	GetBidAttachments(ctx context.Context, username string, bidID uuid.UUID) ([]entity.Attachment, error)
+	GetBidAttachment(ctx context.Context, username string, bidID uuid.UUID, attachmentID uuid.UUID) (*entity.Attachment, io.ReadCloser, error)
+	RemoveBidAttachment(ctx context.Context, username string, bidID uuid.UUID, attachmentID uuid.UUID) error
+}
@@ -9,13 +9,20 @@ import (
 
 type BidUsecase interface {
 	CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bid, error)
-	GetMyBids(ctx context.Context, username string, pag *entity.Pagination) ([]entity.Bid, error)
-	GetTenderBidsList(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) ([]entity.Bid, error)
+	GetMyBids(ctx context.Context, username string, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Bid], error)
+	GetTenderBidsList(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Bid], error)
+	ExportTenderBids(ctx context.Context, username string, tenderID uuid.UUID, query *entity.ListQuery, fn func(*entity.Bid) error) error
 	GetBidStatus(ctx context.Context, username string, bidID uuid.UUID) (entity.BidStatusType, error)
 	UpdateBidStatus(ctx context.Context, username string, bidID uuid.UUID, newStatus entity.BidStatusType) (*entity.Bid, error)
+	UpdateBidsStatus(ctx context.Context, username string, bidIDs uuid.UUIDs, newStatus entity.BidStatusType, atomic bool) ([]entity.BatchItem[entity.Bid], error)
 	PatchBid(ctx context.Context, username string, bidID uuid.UUID, bid *entity.Bid) (*entity.Bid, error)
 	SubmitDecision(ctx context.Context, username string, bidID uuid.UUID, decision entity.BidDecisionType) (*entity.Bid, error)
-	FeedbackBid(ctx context.Context, username string, bidID uuid.UUID, bidFeedback string) (*entity.Bid, error)
+	SubmitDecisions(ctx context.Context, username string, bidIDs uuid.UUIDs, decision entity.BidDecisionType, atomic bool) ([]entity.BatchItem[entity.Bid], error)
+	WithdrawBid(ctx context.Context, username string, bidID uuid.UUID, reason string) (*entity.Bid, error)
+	ResubmitBid(ctx context.Context, username string, bidID uuid.UUID) (*entity.Bid, error)
+	GetBidHistory(ctx context.Context, username string, bidID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.Bid], error)
+	FeedbackBid(ctx context.Context, username string, bidID uuid.UUID, bidFeedback string, rating entity.ReviewRating) (*entity.Bid, error)
 	RollbackBid(ctx context.Context, username string, bidID uuid.UUID, version int) (*entity.Bid, error)
-	CheckPrevFeedbacks(ctx context.Context, tenderID uuid.UUID, author string, requester string, pagination entity.Pagination) ([]entity.BidRewiew, error)
+	CheckPrevFeedbacks(ctx context.Context, tenderID uuid.UUID, author string, requester string, pagination entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.BidRewiew], error)
+	ExportPrevFeedbacks(ctx context.Context, tenderID uuid.UUID, author string, requester string, query *entity.ListQuery, fn func(*entity.BidRewiew) error) error
 }
@@ -0,0 +1,12 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+)
+
+type EventStreamUsecase interface {
+	Subscribe(ctx context.Context, username string, filter entity.StreamFilter, lastEventID *int64) (*entity.StreamSubscription, error)
+	Refresh(ctx context.Context, sub *entity.StreamSubscription) error
+	Unsubscribe(sub *entity.StreamSubscription)
+}
@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type MessageUsecase interface {
+	SendMessage(ctx context.Context, username string, bidID uuid.UUID, text string) (*entity.BidMessage, error)
+	GetMessages(ctx context.Context, username string, bidID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.BidMessage], error)
+	MarkRead(ctx context.Context, username string, bidID uuid.UUID) (*entity.MessageCount, error)
+	GetUnreadCount(ctx context.Context, username string, bidID uuid.UUID) (*entity.MessageCount, error)
+}
@@ -0,0 +1,18 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotificationUsecase interface {
+	GetNotifications(ctx context.Context, username string, unreadOnly bool, pag *entity.Pagination) (*entity.Page[entity.Notification], error)
+	GetNotificationCount(ctx context.Context, username string) (*entity.NotificationCount, error)
+	MarkRead(ctx context.Context, username string, notificationID uuid.UUID) (*entity.Notification, error)
+	MarkAllRead(ctx context.Context, username string) (*entity.NotificationCount, error)
+
+	GetPreferences(ctx context.Context, username string) ([]entity.NotificationPreference, error)
+	SetPreferences(ctx context.Context, username string, prefs []entity.NotificationPreference) ([]entity.NotificationPreference, error)
+}
@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type OpeningUsecase interface {
+	VoteOpening(ctx context.Context, username string, tenderID uuid.UUID) (*entity.OpeningVotes, error)
+	GetOpeningProtocol(ctx context.Context, username string, tenderID uuid.UUID) (*entity.OpeningProtocol, error)
+}
@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationUsecase interface {
+	CreateOrganization(ctx context.Context, username string, org *entity.Organization) (*entity.Organization, error)
+
+	GetOrganization(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error)
+	GetOrganizations(ctx context.Context, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Organization], error)
+	GetMyOrganizations(ctx context.Context, username string, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Organization], error)
+
+	PatchOrganization(ctx context.Context, username string, orgID uuid.UUID, patchOrg *entity.Organization) (*entity.Organization, error)
+
+	GetResponsibles(ctx context.Context, username string, orgID uuid.UUID) ([]entity.OrganizationMember, error)
+	RemoveResponsible(ctx context.Context, username string, orgID uuid.UUID, userID uuid.UUID) error
+	SetResponsibleRole(ctx context.Context, username string, orgID uuid.UUID, userID uuid.UUID, role entity.OrganizationRole) (*entity.Membership, error)
+
+	InviteUser(ctx context.Context, username string, orgID uuid.UUID, inviteeUsername string, role entity.OrganizationRole) (*entity.OrganizationInvitation, error)
+	GetOrgInvitations(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.OrganizationInvitation], error)
+	GetMyInvitations(ctx context.Context, username string, pag *entity.Pagination) (*entity.Page[entity.OrganizationInvitation], error)
+	ResolveInvitation(ctx context.Context, username string, invitationID uuid.UUID, status entity.InvitationStatusType) (*entity.OrganizationInvitation, error)
+
+	GetAudit(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.OrganizationAudit], error)
+}
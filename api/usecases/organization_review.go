@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type OrgReviewUsecase interface {
+	ReviewOrganization(ctx context.Context, username string, bidID uuid.UUID, review *entity.OrganizationReview) (*entity.OrganizationReview, error)
+	GetOrganizationReviews(ctx context.Context, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.OrganizationReview], error)
+	GetOrganizationRating(ctx context.Context, orgID uuid.UUID) (*entity.OrganizationRating, error)
+}
@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type QuestionUsecase interface {
+	AskQuestion(ctx context.Context, username string, tenderID uuid.UUID, question string) (*entity.TenderQuestion, error)
+	GetQuestions(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.TenderQuestion], error)
+	GetPendingQuestions(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.TenderQuestion], error)
+	GetMyQuestions(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.TenderQuestion], error)
+	AnswerQuestion(ctx context.Context, username string, questionID uuid.UUID, answer string) (*entity.TenderQuestion, error)
+}
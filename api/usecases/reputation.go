@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ReputationUsecase interface {
+	GetUserReputation(ctx context.Context, username string) (*entity.Reputation, error)
+	GetOrganizationReputation(ctx context.Context, orgID uuid.UUID) (*entity.Reputation, error)
+}
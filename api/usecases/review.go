@@ -0,0 +1,18 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ReviewUsecase interface {
+	EditReview(ctx context.Context, username string, reviewID uuid.UUID, description string, rating entity.ReviewRating) (*entity.BidRewiew, error)
+	DeleteReview(ctx context.Context, username string, reviewID uuid.UUID) error
+	GetReviewHistory(ctx context.Context, username string, reviewID uuid.UUID) ([]entity.ReviewVersion, error)
+	ReplyReview(ctx context.Context, username string, reviewID uuid.UUID, reply string) (*entity.BidRewiew, error)
+	FlagReview(ctx context.Context, username string, reviewID uuid.UUID, reason string) (*entity.BidRewiew, error)
+	GetModerationQueue(ctx context.Context, username string, pag *entity.Pagination) (*entity.Page[entity.BidRewiew], error)
+	ModerateReview(ctx context.Context, username string, reviewID uuid.UUID, decision entity.ReviewDecision) (*entity.BidRewiew, error)
+}
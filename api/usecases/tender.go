@@ -10,11 +10,14 @@ import (
 type TenderUsecase interface {
 	CreateTender(ctx context.Context, username string, tender *entity.Tender) (*entity.Tender, error)
 
-	GetTenders(ctx context.Context, serviceTypes []entity.TenderServiceType, pag *entity.Pagination) ([]entity.Tender, error)
-	GetMyTenders(ctx context.Context, username string, pag *entity.Pagination) ([]entity.Tender, error)
+	GetTenders(ctx context.Context, serviceTypes []entity.TenderServiceType, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Tender], error)
+	GetMyTenders(ctx context.Context, username string, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Tender], error)
+	ExportTenders(ctx context.Context, serviceTypes []entity.TenderServiceType, query *entity.ListQuery, fn func(*entity.Tender) error) error
+	ExportMyTenders(ctx context.Context, username string, query *entity.ListQuery, fn func(*entity.Tender) error) error
 	GetTenderStatus(ctx context.Context, username string, tenderID uuid.UUID) (entity.TenderStatusType, error)
 
 	UpdateTenderStatus(ctx context.Context, username string, tenderID uuid.UUID, status entity.TenderStatusType) (*entity.Tender, error)
+	UpdateTendersStatus(ctx context.Context, username string, tenderIDs uuid.UUIDs, status entity.TenderStatusType, atomic bool) ([]entity.BatchItem[entity.Tender], error)
 	PatchTender(ctx context.Context, username string, tenderID uuid.UUID, patchTender *entity.Tender) (*entity.Tender, error)
 
 	RollbackTender(ctx context.Context, username string, tenderID uuid.UUID, version int) (*entity.Tender, error)
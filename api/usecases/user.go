@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+)
+
+type UserUsecase interface {
+	Register(ctx context.Context, user *entity.User, password string) (*entity.User, error)
+
+	GetUser(ctx context.Context, username string) (*entity.User, error)
+	GetMe(ctx context.Context, username string) (*entity.UserProfile, error)
+
+	PatchMe(ctx context.Context, username string, patchUser *entity.User) (*entity.User, error)
+	DeactivateMe(ctx context.Context, username string, password string) error
+}
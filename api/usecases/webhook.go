@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WebhookUsecase interface {
+	CreateWebhook(ctx context.Context, username string, orgID uuid.UUID, webhook *entity.Webhook) (*entity.Webhook, error)
+	GetWebhooks(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.Webhook], error)
+	DeleteWebhook(ctx context.Context, username string, webhookID uuid.UUID) error
+	GetDeliveries(ctx context.Context, username string, webhookID uuid.UUID, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.WebhookDelivery], error)
+	Redeliver(ctx context.Context, username string, webhookID uuid.UUID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error)
+}
@@ -1,11 +1,30 @@
 package main
 
 import (
+	"avito/api/controllers/apikey"
+	"avito/api/controllers/attachment"
 	"avito/api/controllers/bid"
+	"avito/api/controllers/events"
+	"avito/api/controllers/message"
+	"avito/api/controllers/notification"
+	"avito/api/controllers/opening"
+	"avito/api/controllers/organization"
+	"avito/api/controllers/orgreview"
 	"avito/api/controllers/ping"
+	"avito/api/controllers/question"
+	"avito/api/controllers/reputation"
+	"avito/api/controllers/review"
 	"avito/api/controllers/tender"
+	"avito/api/controllers/user"
+	"avito/api/controllers/webhook"
+	"avito/api/middlewares"
+	"avito/api/parsers"
+	"avito/internal/authz"
+	"avito/internal/blob"
 	"avito/internal/config"
 	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/mail"
 	"avito/internal/usecases"
 	"fmt"
 	"net/http"
@@ -28,6 +47,8 @@ func ctxTimeoutMiddleware(next http.Handler) http.Handler {
 func main() {
 	cfg := config.LoadEnv()
 
+	parsers.SetCursorSecret(cfg.Server.CursorSecret)
+
 	tenderRepo, err := repos.NewTenderRepo(&cfg.DB)
 	if err != nil {
 		panic(fmt.Errorf("create repo: %w", err))
@@ -38,20 +59,159 @@ func main() {
 		panic(fmt.Errorf("create repo: %w", err))
 	}
 
-	tenderUsecase := usecases.NewTenderUsecase(tenderRepo)
-	bidUsecase := usecases.NewBidUsecase(tenderRepo, bidRepo, tenderUsecase)
+	orgRepo, err := repos.NewOrganizationRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	userRepo, err := repos.NewUserRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	apiKeyRepo, err := repos.NewApiKeyRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	outboxRepo, err := repos.NewOutboxRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	webhookRepo, err := repos.NewWebhookRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	notificationRepo, err := repos.NewNotificationRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	emailRepo, err := repos.NewEmailRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	attachmentRepo, err := repos.NewAttachmentRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	blobStore, err := blob.NewFSStore(cfg.Attachment.Dir)
+	if err != nil {
+		panic(fmt.Errorf("create blob store: %w", err))
+	}
+
+	questionRepo, err := repos.NewQuestionRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	messageRepo, err := repos.NewMessageRepo(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create repo: %w", err))
+	}
+
+	txManager, err := repos.NewTxManager(&cfg.DB)
+	if err != nil {
+		panic(fmt.Errorf("create tx manager: %w", err))
+	}
+
+	policy, err := authz.LoadPolicy(cfg.Authz.PolicyFile)
+	if err != nil {
+		panic(fmt.Errorf("load policy: %w", err))
+	}
+	authorizer := authz.NewPolicyAuthorizer(policy)
+
+	tenderUsecase := usecases.NewTenderUsecase(tenderRepo, orgRepo, userRepo, outboxRepo, txManager, authorizer)
+	bidUsecase := usecases.NewBidUsecase(tenderRepo, bidRepo, orgRepo, tenderUsecase, &cfg.Bid)
+	orgUsecase := usecases.NewOrganizationUsecase(orgRepo, tenderUsecase)
+	userUsecase := usecases.NewUserUsecase(userRepo, orgRepo, tenderUsecase)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(apiKeyRepo, orgRepo, tenderUsecase)
+	webhookUsecase := usecases.NewWebhookUsecase(webhookRepo, orgRepo, tenderUsecase)
+	questionUsecase := usecases.NewQuestionUsecase(questionRepo, tenderRepo, tenderUsecase, &cfg.Tender)
+	orgReviewUsecase := usecases.NewOrgReviewUsecase(orgRepo, tenderRepo, bidUsecase)
+	reviewUsecase := usecases.NewReviewUsecase(bidRepo, bidUsecase, tenderUsecase, &cfg.Review)
+	reputationUsecase := usecases.NewReputationUsecase(bidRepo, orgRepo, tenderUsecase, &cfg.Reputation)
+	messageUsecase := usecases.NewMessageUsecase(messageRepo, tenderRepo, bidUsecase)
+	openingUsecase := usecases.NewOpeningUsecase(tenderRepo, bidRepo, tenderUsecase, &cfg.Tender)
+	attachmentUsecase := usecases.NewAttachmentUsecase(attachmentRepo, blobStore, tenderRepo, bidRepo, tenderUsecase, bidUsecase, &cfg.Attachment)
+
+	mailTemplates, err := mail.LoadTemplates(cfg.Mail.DefaultLocale)
+	if err != nil {
+		panic(fmt.Errorf("load mail templates: %w", err))
+	}
+	mailer, err := mail.NewMailer(&cfg.Mail)
+	if err != nil {
+		panic(fmt.Errorf("create mailer: %w", err))
+	}
+
+	notificationUsecase := usecases.NewNotificationUsecase(notificationRepo, emailRepo, userRepo, tenderRepo, bidRepo, orgRepo, mailTemplates, tenderUsecase)
+
+	eventBus := usecases.NewEventBus(outboxRepo, txManager, &cfg.Events)
+	eventBus.Subscribe("tender_closer", bidUsecase.CloseTenderOnQuorum, entity.EventBidDecision)
+	eventBus.Subscribe("webhooks", webhookUsecase.EnqueueDeliveries)
+	eventBus.Subscribe("notifications", notificationUsecase.HandleEvent,
+		entity.EventBidDecision, entity.EventReviewCreated, entity.EventTenderUpdated, entity.EventTenderClosed, entity.EventBidPublished,
+		entity.EventBidWithdrawn, entity.EventTenderOpened,
+	)
+
+	go eventBus.Run(context.Background())
+	go usecases.NewWebhookDispatcher(webhookRepo, &cfg.Webhook).Run(context.Background())
+	go usecases.NewEmailDispatcher(emailRepo, mailer, &cfg.Mail).Run(context.Background())
+	go openingUsecase.Run(context.Background())
+
+	eventStream := usecases.NewEventStream(outboxRepo, tenderRepo, bidRepo, apiKeyRepo, repos.NewEventListener(&cfg.DB), tenderUsecase)
+	go eventStream.Run(context.Background())
 
 	pingController := ping.Controller{}
 	tenderController := tender.NewTenderController(tenderUsecase)
 	bidController := bid.NewBidController(bidUsecase)
+	orgController := organization.NewOrganizationController(orgUsecase)
+	userController := user.NewUserController(userUsecase)
+	apiKeyController := apikey.NewApiKeyController(apiKeyUsecase)
+	webhookController := webhook.NewWebhookController(webhookUsecase)
+	eventsController := events.NewEventsController(eventStream)
+	notificationController := notification.NewNotificationController(notificationUsecase)
+	attachmentController := attachment.NewAttachmentController(attachmentUsecase)
+	questionController := question.NewQuestionController(questionUsecase)
+	messageController := message.NewMessageController(messageUsecase)
+	reputationController := reputation.NewReputationController(reputationUsecase)
+	reviewController := review.NewReviewController(reviewUsecase)
+	orgReviewController := orgreview.NewOrgReviewController(orgReviewUsecase)
+	openingController := opening.NewOpeningController(openingUsecase)
 
 	r := mux.NewRouter()
+	// stream is long lived, so it is registered out of api subrouter with request timeout
+	r.Handle("/api/events/stream", middlewares.ApiKeyAuth(apiKeyUsecase)(http.HandlerFunc(eventsController.Stream))).Methods("GET")
+	// exports stream rows until done, request timeout would cut file after status is sent
+	exports := r.PathPrefix("/api/").Subrouter()
+	exports.Use(middlewares.ApiKeyAuth(apiKeyUsecase))
+	exports.HandleFunc("/tenders/export", tenderController.ExportTenders).Methods("GET")
+	exports.HandleFunc("/tenders/my/export", tenderController.ExportMyTenders).Methods("GET")
+	exports.HandleFunc("/bids/{tenderId}/reviews/export", bidController.ExportPrevRewiews).Methods("GET")
+	exports.HandleFunc("/bids/{tenderId}/list/export", bidController.ExportTenderBids).Methods("GET")
+
 	api := r.PathPrefix("/api/").Subrouter()
 	api.Use(ctxTimeoutMiddleware)
+	api.Use(middlewares.ApiKeyAuth(apiKeyUsecase))
 	// api.Use(dbgMiddleware)
 
 	api.HandleFunc("/ping", pingController.Ping).Methods("GET")
 
+	api.HandleFunc("/tenders/batch/status", tenderController.UpdateTendersStatus).Methods("PUT")
+	api.HandleFunc("/tenders/{tenderId}/attachments/{attachmentId}", attachmentController.GetTenderAttachment).Methods("GET")
+	api.HandleFunc("/tenders/{tenderId}/attachments/{attachmentId}", attachmentController.RemoveTenderAttachment).Methods("DELETE")
+	api.HandleFunc("/tenders/{tenderId}/attachments", attachmentController.AddTenderAttachment).Methods("POST")
+	api.HandleFunc("/tenders/{tenderId}/attachments", attachmentController.GetTenderAttachments).Methods("GET")
+	api.HandleFunc("/tenders/{tenderId}/questions/pending", questionController.GetPendingQuestions).Methods("GET")
+	api.HandleFunc("/tenders/{tenderId}/questions/my", questionController.GetMyQuestions).Methods("GET")
+	api.HandleFunc("/tenders/{tenderId}/questions", questionController.AskQuestion).Methods("POST")
+	api.HandleFunc("/tenders/{tenderId}/questions", questionController.GetQuestions).Methods("GET")
+	api.HandleFunc("/tenders/{tenderId}/opening_protocol", openingController.GetOpeningProtocol).Methods("GET")
+	api.HandleFunc("/tenders/{tenderId}/open", openingController.VoteOpening).Methods("PUT")
 	api.HandleFunc("/tenders/{tenderId}/rollback/{version}", tenderController.RollbackTender).Methods("PUT")
 	api.HandleFunc("/tenders/{tenderId}/status", tenderController.GetTenderStatus).Methods("GET")
 	api.HandleFunc("/tenders/{tenderId}/status", tenderController.UpdateTenderStatus).Methods("PUT")
@@ -60,6 +220,20 @@ func main() {
 	api.HandleFunc("/tenders/my", tenderController.GetMyTenders).Methods("GET")
 	api.HandleFunc("/tenders", tenderController.GetTenders).Methods("GET")
 
+	api.HandleFunc("/bids/batch/status", bidController.UpdateBidsStatus).Methods("PUT")
+	api.HandleFunc("/bids/batch/submit_decision", bidController.SubmitDecisionBids).Methods("PUT")
+	api.HandleFunc("/bids/{bidId}/attachments/{attachmentId}", attachmentController.GetBidAttachment).Methods("GET")
+	api.HandleFunc("/bids/{bidId}/attachments/{attachmentId}", attachmentController.RemoveBidAttachment).Methods("DELETE")
+	api.HandleFunc("/bids/{bidId}/attachments", attachmentController.AddBidAttachment).Methods("POST")
+	api.HandleFunc("/bids/{bidId}/attachments", attachmentController.GetBidAttachments).Methods("GET")
+	api.HandleFunc("/bids/{bidId}/messages/unread_count", messageController.GetUnreadCount).Methods("GET")
+	api.HandleFunc("/bids/{bidId}/messages/read", messageController.MarkRead).Methods("PUT")
+	api.HandleFunc("/bids/{bidId}/messages", messageController.SendMessage).Methods("POST")
+	api.HandleFunc("/bids/{bidId}/messages", messageController.GetMessages).Methods("GET")
+	api.HandleFunc("/bids/{bidId}/organization_review", orgReviewController.ReviewOrganization).Methods("POST")
+	api.HandleFunc("/bids/{bidId}/withdraw", bidController.WithdrawBid).Methods("PUT")
+	api.HandleFunc("/bids/{bidId}/resubmit", bidController.ResubmitBid).Methods("PUT")
+	api.HandleFunc("/bids/{bidId}/history", bidController.GetBidHistory).Methods("GET")
 	api.HandleFunc("/bids/{bidId}/rollback/{version}", bidController.RollbackBid).Methods("PUT")
 	api.HandleFunc("/bids/{tenderId}/reviews", bidController.PrevRewiews).Methods("GET")
 	api.HandleFunc("/bids/{bidId}/feedback", bidController.FeedbackBid).Methods("PUT")
@@ -71,5 +245,59 @@ func main() {
 	api.HandleFunc("/bids/my", bidController.GetMyBids).Methods("GET")
 	api.HandleFunc("/bids/new", bidController.CreateBid).Methods("POST")
 
+	api.HandleFunc("/organizations/{organizationId}/responsibles/{userId}/role", orgController.SetResponsibleRole).Methods("PUT")
+	api.HandleFunc("/organizations/{organizationId}/responsibles/{userId}", orgController.RemoveResponsible).Methods("DELETE")
+	api.HandleFunc("/organizations/{organizationId}/responsibles", orgController.GetResponsibles).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/invitations", orgController.InviteUser).Methods("POST")
+	api.HandleFunc("/organizations/{organizationId}/invitations", orgController.GetOrgInvitations).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/audit", orgController.GetAudit).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/api-keys", apiKeyController.CreateApiKey).Methods("POST")
+	api.HandleFunc("/organizations/{organizationId}/api-keys", apiKeyController.GetApiKeys).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/webhooks", webhookController.CreateWebhook).Methods("POST")
+	api.HandleFunc("/organizations/{organizationId}/webhooks", webhookController.GetWebhooks).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/reviews", orgReviewController.GetOrganizationReviews).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/rating", orgReviewController.GetOrganizationRating).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/reputation", reputationController.GetOrganizationReputation).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}/edit", orgController.PatchOrganization).Methods("PATCH")
+	api.HandleFunc("/organizations/new", orgController.CreateOrganization).Methods("POST")
+	api.HandleFunc("/organizations/my", orgController.GetMyOrganizations).Methods("GET")
+	api.HandleFunc("/organizations/{organizationId}", orgController.GetOrganization).Methods("GET")
+	api.HandleFunc("/organizations", orgController.GetOrganizations).Methods("GET")
+
+	api.HandleFunc("/invitations/{invitationId}/accept", orgController.AcceptInvitation).Methods("PUT")
+	api.HandleFunc("/invitations/{invitationId}/decline", orgController.DeclineInvitation).Methods("PUT")
+	api.HandleFunc("/invitations/{invitationId}/revoke", orgController.RevokeInvitation).Methods("PUT")
+	api.HandleFunc("/invitations/my", orgController.GetMyInvitations).Methods("GET")
+
+	api.HandleFunc("/api-keys/{apiKeyId}/revoke", apiKeyController.RevokeApiKey).Methods("PUT")
+
+	api.HandleFunc("/questions/{questionId}/answer", questionController.AnswerQuestion).Methods("PUT")
+
+	api.HandleFunc("/reviews/moderation", reviewController.GetModerationQueue).Methods("GET")
+	api.HandleFunc("/reviews/{reviewId}/moderate", reviewController.ModerateReview).Methods("PUT")
+	api.HandleFunc("/reviews/{reviewId}/history", reviewController.GetReviewHistory).Methods("GET")
+	api.HandleFunc("/reviews/{reviewId}/reply", reviewController.ReplyReview).Methods("PUT")
+	api.HandleFunc("/reviews/{reviewId}/flag", reviewController.FlagReview).Methods("PUT")
+	api.HandleFunc("/reviews/{reviewId}/edit", reviewController.EditReview).Methods("PATCH")
+	api.HandleFunc("/reviews/{reviewId}", reviewController.DeleteReview).Methods("DELETE")
+
+	api.HandleFunc("/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver", webhookController.Redeliver).Methods("PUT")
+	api.HandleFunc("/webhooks/{webhookId}/deliveries", webhookController.GetDeliveries).Methods("GET")
+	api.HandleFunc("/webhooks/{webhookId}", webhookController.DeleteWebhook).Methods("DELETE")
+
+	api.HandleFunc("/notifications/preferences", notificationController.GetPreferences).Methods("GET")
+	api.HandleFunc("/notifications/preferences", notificationController.SetPreferences).Methods("PUT")
+	api.HandleFunc("/notifications/unread_count", notificationController.GetNotificationCount).Methods("GET")
+	api.HandleFunc("/notifications/read_all", notificationController.MarkAllRead).Methods("PUT")
+	api.HandleFunc("/notifications/{notificationId}/read", notificationController.MarkRead).Methods("PUT")
+	api.HandleFunc("/notifications", notificationController.GetNotifications).Methods("GET")
+
+	api.HandleFunc("/users/register", userController.Register).Methods("POST")
+	api.HandleFunc("/users/me/deactivate", userController.DeactivateMe).Methods("PUT")
+	api.HandleFunc("/users/me/edit", userController.PatchMe).Methods("PATCH")
+	api.HandleFunc("/users/me", userController.GetMe).Methods("GET")
+	api.HandleFunc("/users/{username}/reputation", reputationController.GetUserReputation).Methods("GET")
+	api.HandleFunc("/users/{username}", userController.GetUser).Methods("GET")
+
 	http.ListenAndServe(cfg.Server.ServerAddress, r)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"avito/internal/authz"
 	"avito/internal/config"
 	"avito/internal/db/models"
 	"avito/internal/db/repos"
@@ -47,9 +48,15 @@ func CreateBids() {
 
 	tenderRepo, _ := repos.NewTenderRepo(&cfg.DB)
 	bidsRepo, _ := repos.NewBidRepo(&cfg.DB)
+	orgRepo, _ := repos.NewOrganizationRepo(&cfg.DB)
+	userRepo, _ := repos.NewUserRepo(&cfg.DB)
+	outboxRepo, _ := repos.NewOutboxRepo(&cfg.DB)
+	txManager, _ := repos.NewTxManager(&cfg.DB)
 
-	tenderUsecase := usecases.NewTenderUsecase(tenderRepo)
-	bidsUsecase := usecases.NewBidUsecase(tenderRepo, bidsRepo, tenderUsecase)
+	policy, _ := authz.LoadPolicy(cfg.Authz.PolicyFile)
+
+	tenderUsecase := usecases.NewTenderUsecase(tenderRepo, orgRepo, userRepo, outboxRepo, txManager, authz.NewPolicyAuthorizer(policy))
+	bidsUsecase := usecases.NewBidUsecase(tenderRepo, bidsRepo, orgRepo, tenderUsecase, &cfg.Bid)
 
 	var tenders []models.Tender
 	db.Find(&tenders)
@@ -0,0 +1,120 @@
+package authz
+
+import (
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Action string
+
+const (
+	ActionOrganizationView   Action = "organization.view"
+	ActionOrganizationManage Action = "organization.manage"
+	ActionResponsibleRemove  Action = "responsible.remove"
+	ActionInvitationRespond  Action = "invitation.respond"
+	ActionTenderCreate       Action = "tender.create"
+	ActionTenderView         Action = "tender.view"
+	ActionTenderEdit         Action = "tender.edit"
+	ActionTenderPublish      Action = "tender.publish"
+	ActionTenderOpen         Action = "tender.open"
+	ActionBidView            Action = "bid.view"
+	ActionBidEdit            Action = "bid.edit"
+	ActionBidVote            Action = "bid.vote"
+	ActionBidReview          Action = "bid.review"
+	ActionBidMessage         Action = "bid.message"
+	ActionReviewView         Action = "review.view"
+	ActionApiKeyManage       Action = "api_key.manage"
+	ActionWebhookManage      Action = "webhook.manage"
+)
+
+var ActionList = []Action{
+	ActionOrganizationView, ActionOrganizationManage, ActionResponsibleRemove, ActionInvitationRespond,
+	ActionTenderCreate, ActionTenderView, ActionTenderEdit, ActionTenderPublish, ActionTenderOpen,
+	ActionBidView, ActionBidEdit, ActionBidVote, ActionBidReview, ActionBidMessage, ActionReviewView,
+	ActionApiKeyManage, ActionWebhookManage,
+}
+
+// ApiKeyActionList is actions which can be granted to api key, membership and key management stay human only,
+// votes too as quorum is counted in people
+var ApiKeyActionList = []Action{
+	ActionOrganizationView,
+	ActionTenderCreate, ActionTenderView, ActionTenderEdit, ActionTenderPublish,
+	ActionBidView, ActionBidEdit, ActionBidReview, ActionBidMessage, ActionReviewView,
+}
+
+// Authorizer decide if actor can do action with resource,
+// error means that decision cant be made, e.g. external policy engine is unavailable
+type Authorizer interface {
+	Can(ctx context.Context, actor *Actor, action Action, resource Resource) (bool, error)
+}
+
+// Actor is user with all his memberships, it is loaded once and reused for all checks in request.
+// For api key requests only ApiKey is set, it acts in key organization within key permissions.
+type Actor struct {
+	User        entity.User
+	Memberships []entity.Membership
+	ApiKey      *entity.ApiKey
+}
+
+func (a *Actor) Ref() entity.ActorRef {
+	if a.ApiKey != nil {
+		return entity.ActorRef{Type: entity.ActorApiKey, ID: a.ApiKey.Id}
+	}
+	return entity.ActorRef{Type: entity.ActorUser, ID: a.User.Id}
+}
+
+func (a *Actor) Role(orgID uuid.UUID) (entity.OrganizationRole, bool) {
+	for _, m := range a.Memberships {
+		if m.OrganizationID == orgID {
+			return m.Role, true
+		}
+	}
+	return "", false
+}
+
+func (a *Actor) OrganizationIDs() uuid.UUIDs {
+	if a.ApiKey != nil {
+		return uuid.UUIDs{a.ApiKey.OrganizationID}
+	}
+
+	ids := uuid.UUIDs{}
+	for _, m := range a.Memberships {
+		ids = append(ids, m.OrganizationID)
+	}
+	return ids
+}
+
+// Resource describe checked object only by attributes needed for policy conditions
+type Resource struct {
+	// organization resource belongs to
+	OrganizationID uuid.UUID
+	// bid author, user or organization
+	AuthorID uuid.UUID
+	// user resource is about, e.g. invitee or removed responsible
+	UserID    uuid.UUID
+	Published bool
+	// bids of sealed tender are not opened yet
+	Sealed bool
+}
+
+func OrganizationResource(orgID uuid.UUID) Resource {
+	return Resource{OrganizationID: orgID}
+}
+
+func MemberResource(orgID uuid.UUID, userID uuid.UUID) Resource {
+	return Resource{OrganizationID: orgID, UserID: userID}
+}
+
+func InvitationResource(invitation *entity.OrganizationInvitation) Resource {
+	return Resource{OrganizationID: invitation.OrganizationID, UserID: invitation.UserID}
+}
+
+func TenderResource(tender *entity.Tender) Resource {
+	return Resource{OrganizationID: tender.OrganizationID, Published: tender.Status == entity.Published}
+}
+
+func BidResource(bid *entity.Bid, tender *entity.Tender) Resource {
+	return Resource{OrganizationID: tender.OrganizationID, AuthorID: bid.AuthorID, Published: bid.Status == entity.BPublished, Sealed: tender.BidsSealed()}
+}
@@ -0,0 +1,18 @@
+package authz
+
+import (
+	"avito/internal/entity"
+	"context"
+)
+
+type apiKeyCtxKey struct{}
+
+func WithApiKey(ctx context.Context, key *entity.ApiKey) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, key)
+}
+
+// ApiKeyFromContext return key request is authenticated with, nil for user requests
+func ApiKeyFromContext(ctx context.Context) *entity.ApiKey {
+	key, _ := ctx.Value(apiKeyCtxKey{}).(*entity.ApiKey)
+	return key
+}
@@ -0,0 +1,146 @@
+package authz
+
+import (
+	"avito/internal/entity"
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed policy.yaml
+var defaultPolicy []byte
+
+type Condition string
+
+const (
+	CondMember       Condition = "member"
+	CondAuthorMember Condition = "author_member"
+	CondAuthor       Condition = "author"
+	CondSelf         Condition = "self"
+	CondPublished    Condition = "published"
+	CondOpened       Condition = "opened"
+)
+
+var ConditionList = []Condition{CondMember, CondAuthorMember, CondAuthor, CondSelf, CondPublished, CondOpened}
+
+type Rule struct {
+	Action     Action                    `yaml:"action"`
+	Roles      []entity.OrganizationRole `yaml:"roles"`
+	Conditions []Condition               `yaml:"conditions"`
+}
+
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadPolicy read policy from file, with empty path embedded default policy is used
+func LoadPolicy(path string) (*Policy, error) {
+	data := defaultPolicy
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read policy file: %w", err)
+		}
+	}
+
+	return ParsePolicy(data)
+}
+
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("unmarshal policy: %w", err)
+	}
+
+	for i, rule := range policy.Rules {
+		if !slices.Contains(ActionList, rule.Action) {
+			return nil, fmt.Errorf("rule %d: unknown action: %s", i, rule.Action)
+		}
+		// rule without conditions allows action to everyone, it is more likely a mistake
+		if len(rule.Conditions) == 0 {
+			return nil, fmt.Errorf("rule %d: no conditions", i)
+		}
+		for _, role := range rule.Roles {
+			if !slices.Contains(entity.OrganizationRoleList, role) {
+				return nil, fmt.Errorf("rule %d: unknown role: %s", i, role)
+			}
+		}
+		for _, cond := range rule.Conditions {
+			if !slices.Contains(ConditionList, cond) {
+				return nil, fmt.Errorf("rule %d: unknown condition: %s", i, cond)
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+// PolicyAuthorizer allow action if any rule for it matches
+type PolicyAuthorizer struct {
+	rules map[Action][]Rule
+}
+
+func NewPolicyAuthorizer(policy *Policy) *PolicyAuthorizer {
+	rules := map[Action][]Rule{}
+	for _, rule := range policy.Rules {
+		rules[rule.Action] = append(rules[rule.Action], rule)
+	}
+
+	return &PolicyAuthorizer{
+		rules: rules,
+	}
+}
+
+func (a *PolicyAuthorizer) Can(_ context.Context, actor *Actor, action Action, resource Resource) (bool, error) {
+	// keys issued before action became human only keep it in permissions
+	if actor.ApiKey != nil && (!slices.Contains(ApiKeyActionList, action) || !slices.Contains(actor.ApiKey.Permissions, string(action))) {
+		return false, nil
+	}
+
+	for _, rule := range a.rules[action] {
+		if matchRule(rule, actor, resource) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchRule(rule Rule, actor *Actor, resource Resource) bool {
+	hasRole := func(orgID uuid.UUID) bool {
+		// api key has no role, its scope is limited by permissions
+		if actor.ApiKey != nil {
+			return actor.ApiKey.OrganizationID == orgID
+		}
+		role, ok := actor.Role(orgID)
+		return ok && (len(rule.Roles) == 0 || slices.Contains(rule.Roles, role))
+	}
+
+	for _, cond := range rule.Conditions {
+		var ok bool
+		switch cond {
+		case CondMember:
+			ok = hasRole(resource.OrganizationID)
+		case CondAuthorMember:
+			ok = hasRole(resource.AuthorID)
+		case CondAuthor:
+			ok = actor.ApiKey == nil && actor.User.Id == resource.AuthorID
+		case CondSelf:
+			ok = actor.ApiKey == nil && actor.User.Id == resource.UserID
+		case CondPublished:
+			ok = resource.Published
+		case CondOpened:
+			ok = !resource.Sealed
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,311 @@
+package authz
+
+import (
+	"avito/internal/entity"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: `
+rules:
+  - action: tender.view
+    roles: [Owner, Viewer]
+    conditions: [member]
+`,
+		},
+		{
+			name: "unknown action",
+			data: `
+rules:
+  - action: tender.delete
+    conditions: [member]
+`,
+			wantErr: "unknown action",
+		},
+		{
+			name: "unknown role",
+			data: `
+rules:
+  - action: tender.view
+    roles: [Admin]
+    conditions: [member]
+`,
+			wantErr: "unknown role",
+		},
+		{
+			name: "unknown condition",
+			data: `
+rules:
+  - action: tender.view
+    conditions: [admin]
+`,
+			wantErr: "unknown condition",
+		},
+		{
+			name: "no conditions",
+			data: `
+rules:
+  - action: tender.view
+`,
+			wantErr: "no conditions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	if _, err := LoadPolicy(""); err != nil {
+		t.Fatalf("load default policy: %v", err)
+	}
+}
+
+func TestCan(t *testing.T) {
+	org := uuid.New()
+	otherOrg := uuid.New()
+	user := entity.User{Id: uuid.New()}
+	otherUser := uuid.New()
+
+	member := func(role entity.OrganizationRole) *Actor {
+		return &Actor{User: user, Memberships: []entity.Membership{{OrganizationID: org, UserID: user.Id, Role: role}}}
+	}
+	key := func(permissions ...Action) *Actor {
+		apiKey := &entity.ApiKey{Id: uuid.New(), OrganizationID: org}
+		for _, p := range permissions {
+			apiKey.Permissions = append(apiKey.Permissions, string(p))
+		}
+		return &Actor{ApiKey: apiKey}
+	}
+	rule := func(action Action, roles []entity.OrganizationRole, conds ...Condition) Rule {
+		return Rule{Action: action, Roles: roles, Conditions: conds}
+	}
+
+	tests := []struct {
+		name     string
+		rule     Rule
+		actor    *Actor
+		action   Action
+		resource Resource
+		want     bool
+	}{
+		// conditions
+		{
+			name:     "member of organization",
+			rule:     rule(ActionTenderView, nil, CondMember),
+			actor:    member(entity.RoleViewer),
+			action:   ActionTenderView,
+			resource: OrganizationResource(org),
+			want:     true,
+		},
+		{
+			name:     "member of other organization",
+			rule:     rule(ActionTenderView, nil, CondMember),
+			actor:    member(entity.RoleViewer),
+			action:   ActionTenderView,
+			resource: OrganizationResource(otherOrg),
+		},
+		{
+			name:     "member of author organization",
+			rule:     rule(ActionBidView, nil, CondAuthorMember),
+			actor:    member(entity.RoleViewer),
+			action:   ActionBidView,
+			resource: Resource{OrganizationID: otherOrg, AuthorID: org},
+			want:     true,
+		},
+		{
+			name:     "not member of author organization",
+			rule:     rule(ActionBidView, nil, CondAuthorMember),
+			actor:    member(entity.RoleViewer),
+			action:   ActionBidView,
+			resource: Resource{OrganizationID: org, AuthorID: otherOrg},
+		},
+		{
+			name:     "author",
+			rule:     rule(ActionBidEdit, nil, CondAuthor),
+			actor:    member(entity.RoleViewer),
+			action:   ActionBidEdit,
+			resource: Resource{OrganizationID: otherOrg, AuthorID: user.Id},
+			want:     true,
+		},
+		{
+			name:     "not author",
+			rule:     rule(ActionBidEdit, nil, CondAuthor),
+			actor:    member(entity.RoleViewer),
+			action:   ActionBidEdit,
+			resource: Resource{OrganizationID: otherOrg, AuthorID: otherUser},
+		},
+		{
+			name:     "self",
+			rule:     rule(ActionInvitationRespond, nil, CondSelf),
+			actor:    &Actor{User: user},
+			action:   ActionInvitationRespond,
+			resource: MemberResource(org, user.Id),
+			want:     true,
+		},
+		{
+			name:     "not self",
+			rule:     rule(ActionInvitationRespond, nil, CondSelf),
+			actor:    &Actor{User: user},
+			action:   ActionInvitationRespond,
+			resource: MemberResource(org, otherUser),
+		},
+		{
+			name:     "published",
+			rule:     rule(ActionTenderView, nil, CondPublished),
+			actor:    &Actor{User: user},
+			action:   ActionTenderView,
+			resource: Resource{OrganizationID: otherOrg, Published: true},
+			want:     true,
+		},
+		{
+			name:     "not published",
+			rule:     rule(ActionTenderView, nil, CondPublished),
+			actor:    &Actor{User: user},
+			action:   ActionTenderView,
+			resource: Resource{OrganizationID: otherOrg},
+		},
+		{
+			name:     "opened",
+			rule:     rule(ActionBidView, nil, CondMember, CondOpened),
+			actor:    member(entity.RoleViewer),
+			action:   ActionBidView,
+			resource: Resource{OrganizationID: org},
+			want:     true,
+		},
+		{
+			name:     "sealed",
+			rule:     rule(ActionBidView, nil, CondMember, CondOpened),
+			actor:    member(entity.RoleViewer),
+			action:   ActionBidView,
+			resource: Resource{OrganizationID: org, Sealed: true},
+		},
+		{
+			name:     "no rule for action",
+			rule:     rule(ActionTenderView, nil, CondMember),
+			actor:    member(entity.RoleOwner),
+			action:   ActionTenderEdit,
+			resource: OrganizationResource(org),
+		},
+
+		// roles
+		{
+			name:     "role allowed",
+			rule:     rule(ActionTenderEdit, []entity.OrganizationRole{entity.RoleOwner, entity.RoleTenderManager}, CondMember),
+			actor:    member(entity.RoleTenderManager),
+			action:   ActionTenderEdit,
+			resource: OrganizationResource(org),
+			want:     true,
+		},
+		{
+			name:     "role not allowed",
+			rule:     rule(ActionTenderEdit, []entity.OrganizationRole{entity.RoleOwner, entity.RoleTenderManager}, CondMember),
+			actor:    member(entity.RoleViewer),
+			action:   ActionTenderEdit,
+			resource: OrganizationResource(org),
+		},
+		{
+			name:     "role of author organization",
+			rule:     rule(ActionBidEdit, []entity.OrganizationRole{entity.RoleOwner}, CondAuthorMember),
+			actor:    member(entity.RoleEvaluator),
+			action:   ActionBidEdit,
+			resource: Resource{OrganizationID: otherOrg, AuthorID: org},
+		},
+
+		// api keys
+		{
+			name:     "key with permission",
+			rule:     rule(ActionTenderView, []entity.OrganizationRole{entity.RoleOwner}, CondMember),
+			actor:    key(ActionTenderView),
+			action:   ActionTenderView,
+			resource: OrganizationResource(org),
+			want:     true,
+		},
+		{
+			name:     "key without permission",
+			rule:     rule(ActionTenderView, nil, CondMember),
+			actor:    key(ActionBidView),
+			action:   ActionTenderView,
+			resource: OrganizationResource(org),
+		},
+		{
+			name:     "key of other organization",
+			rule:     rule(ActionTenderView, nil, CondMember),
+			actor:    key(ActionTenderView),
+			action:   ActionTenderView,
+			resource: OrganizationResource(otherOrg),
+		},
+		{
+			name:     "key for author organization",
+			rule:     rule(ActionBidEdit, nil, CondAuthorMember),
+			actor:    key(ActionBidEdit),
+			action:   ActionBidEdit,
+			resource: Resource{OrganizationID: otherOrg, AuthorID: org},
+			want:     true,
+		},
+		{
+			name:     "key is not author",
+			rule:     rule(ActionBidEdit, nil, CondAuthor),
+			actor:    key(ActionBidEdit),
+			action:   ActionBidEdit,
+			resource: Resource{OrganizationID: otherOrg, AuthorID: uuid.Nil},
+		},
+		{
+			name:     "key is not self",
+			rule:     rule(ActionInvitationRespond, nil, CondSelf),
+			actor:    key(ActionInvitationRespond),
+			action:   ActionInvitationRespond,
+			resource: MemberResource(org, uuid.Nil),
+		},
+		{
+			name:     "key with vote permission",
+			rule:     rule(ActionBidVote, nil, CondMember),
+			actor:    key(ActionBidVote),
+			action:   ActionBidVote,
+			resource: OrganizationResource(org),
+		},
+		{
+			name:     "key with open permission",
+			rule:     rule(ActionTenderOpen, nil, CondMember),
+			actor:    key(ActionTenderOpen),
+			action:   ActionTenderOpen,
+			resource: OrganizationResource(org),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer := NewPolicyAuthorizer(&Policy{Rules: []Rule{tt.rule}})
+			got, err := authorizer.Can(context.Background(), tt.actor, tt.action, tt.resource)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
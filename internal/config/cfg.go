@@ -2,23 +2,106 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Server Server
-	DB     DB
+	Server     Server
+	DB         DB
+	Authz      Authz
+	Events     Events
+	Webhook    Webhook
+	Mail       Mail
+	Attachment Attachment
+	Tender     Tender
+	Bid        Bid
+	Reputation Reputation
+	Review     Review
 }
 
 type Server struct {
 	ServerAddress string `env:"SERVER_ADDRESS" env-required:"true"`
+	CursorSecret  string `env:"CURSOR_SECRET"`
 }
 
 type DB struct {
 	PostgresConn string `env:"POSTGRES_CONN" env-required:"true"`
 }
 
+type Authz struct {
+	// empty means embedded default policy
+	PolicyFile string `env:"POLICY_FILE"`
+}
+
+type Events struct {
+	// attempts to handle event before it is left in outbox, delay between attempts doubles
+	MaxAttempts  int           `env:"EVENT_MAX_ATTEMPTS" env-default:"10"`
+	RetryDelay   time.Duration `env:"EVENT_RETRY_DELAY" env-default:"5s"`
+	PollInterval time.Duration `env:"EVENT_POLL_INTERVAL" env-default:"1s"`
+	// time to handle claimed batch before other worker can take it
+	Lease time.Duration `env:"EVENT_LEASE" env-default:"1m"`
+}
+
+type Webhook struct {
+	// attempts before delivery is failed, delay between attempts doubles
+	MaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+	RetryDelay   time.Duration `env:"WEBHOOK_RETRY_DELAY" env-default:"10s"`
+	Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+	PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" env-default:"1s"`
+}
+
+type Mail struct {
+	// smtp or file, file writes messages to maildir for local development
+	Transport     string `env:"MAIL_TRANSPORT" env-default:"file"`
+	From          string `env:"MAIL_FROM" env-default:"noreply@tender.local"`
+	Dir           string `env:"MAIL_DIR" env-default:"mail"`
+	SMTPAddr      string `env:"MAIL_SMTP_ADDR"`
+	SMTPUsername  string `env:"MAIL_SMTP_USERNAME"`
+	SMTPPassword  string `env:"MAIL_SMTP_PASSWORD"`
+	DefaultLocale string `env:"MAIL_DEFAULT_LOCALE" env-default:"ru"`
+
+	// attempts before email is failed, delay between attempts doubles
+	MaxAttempts  int           `env:"MAIL_MAX_ATTEMPTS" env-default:"8"`
+	RetryDelay   time.Duration `env:"MAIL_RETRY_DELAY" env-default:"30s"`
+	Timeout      time.Duration `env:"MAIL_TIMEOUT" env-default:"30s"`
+	PollInterval time.Duration `env:"MAIL_POLL_INTERVAL" env-default:"5s"`
+}
+
+type Attachment struct {
+	// directory of local blob store
+	Dir      string `env:"ATTACHMENT_DIR" env-default:"attachments"`
+	MaxSize  int64  `env:"ATTACHMENT_MAX_SIZE" env-default:"20971520"`
+	MaxCount int    `env:"ATTACHMENT_MAX_COUNT" env-default:"20"`
+	// media types detected by content, office documents are detected as zip
+	AllowedTypes []string `env:"ATTACHMENT_ALLOWED_TYPES" env-separator:"," env-default:"application/pdf,image/png,image/jpeg,image/gif,image/webp,application/zip,text/plain"`
+}
+
+type Tender struct {
+	// questions are closed this long before tender deadline, so answers can be considered in bids
+	QuestionCutoff time.Duration `env:"TENDER_QUESTION_CUTOFF" env-default:"24h"`
+	// how often sealed tenders with passed deadline are looked for to open
+	OpenPollInterval time.Duration `env:"TENDER_OPEN_POLL_INTERVAL" env-default:"1m"`
+}
+
+type Bid struct {
+	// how many times withdrawn bid can be published again
+	MaxResubmissions int `env:"BID_MAX_RESUBMISSIONS" env-default:"2"`
+}
+
+type Review struct {
+	// reviewer can edit review this long after creation
+	EditWindow time.Duration `env:"REVIEW_EDIT_WINDOW" env-default:"48h"`
+	// usernames who resolve flagged reviews
+	Moderators []string `env:"REVIEW_MODERATORS" env-separator:","`
+}
+
+type Reputation struct {
+	// trend compares reviews of last window with earlier ones
+	TrendWindow time.Duration `env:"REPUTATION_TREND_WINDOW" env-default:"2160h"`
+}
+
 func LoadEnv() *Config {
 	var cfg Config
 	err := cleanenv.ReadEnv(&cfg)
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ApiKeyName = "api_key"
+
+type ApiKey struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	Name        string     `gorm:"type:varchar(100);not null"`
+	Prefix      string     `gorm:"type:varchar(20);unique;not null"`
+	Hash        string     `gorm:"type:varchar(64);not null"`
+	Permissions StringList `gorm:"type:jsonb;not null"`
+
+	CreatorID  uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt  time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	LastUsedAt *time.Time `gorm:"type:timestamp"`
+	RevokedAt  *time.Time `gorm:"type:timestamp"`
+}
+
+func (ApiKey) TableName() string {
+	return ApiKeyName
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const AttachmentName = "attachment"
+
+type Attachment struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	ParentType string    `gorm:"type:varchar(10);not null;index:idx_attachment_parent"`
+	ParentID   uuid.UUID `gorm:"type:uuid;not null;index:idx_attachment_parent"`
+
+	ActorType string    `gorm:"type:varchar(10);not null"`
+	ActorID   uuid.UUID `gorm:"type:uuid;not null"`
+
+	Name        string    `gorm:"type:varchar(255);not null"`
+	ContentType string    `gorm:"type:varchar(100);not null"`
+	Size        int64     `gorm:"not null"`
+	SHA256      string    `gorm:"column:sha256;type:char(64);not null"`
+	StorageKey  string    `gorm:"type:varchar(100);not null"`
+	CreatedAt   time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (Attachment) TableName() string {
+	return AttachmentName
+}
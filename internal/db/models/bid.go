@@ -82,6 +82,16 @@ type Bid struct {
 
 	ShipsCount int `gorm:"type:bigint;default:0;not null"`
 	Kvorum     int `gorm:"type:bigint;not null"`
+	// not versioned, rollback keeps decision
+	Decision string `gorm:"type:varchar(10)"`
+
+	WithdrawReason string `gorm:"type:varchar(500)"`
+	Resubmissions  int    `gorm:"type:bigint;default:0;not null"`
+
+	AttachmentIDs UUIDList `gorm:"type:jsonb;not null;default:'[]'"`
+
+	ActorType string     `gorm:"type:varchar(10)"`
+	ActorID   *uuid.UUID `gorm:"type:uuid"`
 }
 
 func (Bid) TableName() string {
@@ -105,6 +115,14 @@ type BidVersion struct {
 
 	ShipsCount int `gorm:"type:bigint;not null"`
 	Kvorum     int `gorm:"type:bigint;not null"`
+
+	WithdrawReason string `gorm:"type:varchar(500)"`
+	Resubmissions  int    `gorm:"type:bigint;default:0;not null"`
+
+	AttachmentIDs UUIDList `gorm:"type:jsonb;not null;default:'[]'"`
+
+	ActorType string     `gorm:"type:varchar(10)"`
+	ActorID   *uuid.UUID `gorm:"type:uuid"`
 }
 
 func (BidVersion) TableName() string {
@@ -134,10 +152,48 @@ type BidRewiew struct {
 	Description string    `gorm:"type:text"`
 	CreatedAt   time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
 
-	BidID uuid.UUID `gorm:"type:uuid"`
-	Bid   Bid       `gorm:"foreignKey:BidID;references:Id;" copier:"-"`
+	UpdatedAt *time.Time `gorm:"type:timestamp"`
+
+	Quality       *int `gorm:"type:smallint"`
+	Timeliness    *int `gorm:"type:smallint"`
+	Communication *int `gorm:"type:smallint"`
+
+	Reply     *string    `gorm:"type:varchar(1000)"`
+	RepliedAt *time.Time `gorm:"type:timestamp"`
+
+	ModerationStatus string     `gorm:"type:varchar(10);not null;default:'Visible';index"`
+	FlagReason       *string    `gorm:"type:varchar(500)"`
+	FlaggedAt        *time.Time `gorm:"type:timestamp"`
+	ModeratedAt      *time.Time `gorm:"type:timestamp"`
+
+	BidID     uuid.UUID  `gorm:"type:uuid;index:idx_review_actor_active,unique,where:deleted_at IS NULL"`
+	Bid       Bid        `gorm:"foreignKey:BidID;references:Id;" copier:"-"`
+	DeletedAt *time.Time `gorm:"type:timestamp"`
+
+	// reviews created before ratings have no actor and dont conflict, deleted review doesnt block new one
+	ActorType string     `gorm:"type:varchar(10)"`
+	ActorID   *uuid.UUID `gorm:"type:uuid;index:idx_review_actor_active,unique,where:deleted_at IS NULL"`
 }
 
 func (BidRewiew) TableName() string {
 	return BidRewiewName
 }
+
+const ReviewVersionName = "bid_rewiew_backup"
+
+type ReviewVersion struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;" copier:"-"`
+
+	ReviewID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Review   BidRewiew `gorm:"foreignKey:ReviewID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	Description   string    `gorm:"type:text"`
+	Quality       *int      `gorm:"type:smallint"`
+	Timeliness    *int      `gorm:"type:smallint"`
+	Communication *int      `gorm:"type:smallint"`
+	EditedAt      time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (ReviewVersion) TableName() string {
+	return ReviewVersionName
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const EmailName = "email_delivery"
+
+type Email struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_email_event"`
+	User   User      `gorm:"foreignKey:UserID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	// one email of type per event and user, like notifications
+	EventID          uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_email_event"`
+	NotificationType string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_email_event"`
+
+	To      string `gorm:"type:varchar(255);not null"`
+	Subject string `gorm:"type:varchar(255);not null"`
+	Body    string `gorm:"type:text;not null"`
+
+	Status        DeliveryStatusType `gorm:"type:delivery_status_type;not null"`
+	Attempts      int                `gorm:"not null;default:0"`
+	LastError     string             `gorm:"type:varchar(500)"`
+	NextAttemptAt *time.Time         `gorm:"type:timestamp;index"`
+	CreatedAt     time.Time          `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt     time.Time          `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (Email) TableName() string {
+	return EmailName
+}
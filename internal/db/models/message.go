@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const BidMessageName = "bid_message"
+
+type BidMessage struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	BidID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Bid   Bid       `gorm:"foreignKey:BidID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	Side      string     `gorm:"type:varchar(10);not null"`
+	Text      string     `gorm:"type:varchar(2000);not null"`
+	ActorType string     `gorm:"type:varchar(10);not null"`
+	ActorID   uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	ReadAt    *time.Time `gorm:"type:timestamp"`
+}
+
+func (BidMessage) TableName() string {
+	return BidMessageName
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const NotificationName = "notification"
+
+type Notification struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_notification_event"`
+	User   User      `gorm:"foreignKey:UserID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	// one notification of type per event and user, so redelivered events dont duplicate
+	EventID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_notification_event"`
+	Type    string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_notification_event"`
+
+	Data      RawJSON    `gorm:"type:jsonb;not null"`
+	ReadAt    *time.Time `gorm:"type:timestamp"`
+	CreatedAt time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (Notification) TableName() string {
+	return NotificationName
+}
+
+const NotificationPreferenceName = "notification_preference"
+
+type NotificationPreference struct {
+	UserID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	User   User      `gorm:"foreignKey:UserID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	Type    string `gorm:"type:varchar(50);primaryKey"`
+	Enabled bool   `gorm:"not null"`
+}
+
+func (NotificationPreference) TableName() string {
+	return NotificationPreferenceName
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const TenderOpeningName = "tender_opening"
+
+type TenderOpening struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	TenderID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Tender   Tender    `gorm:"foreignKey:TenderID;references:Id;" copier:"-"`
+
+	Reason   string    `gorm:"type:varchar(10);not null"`
+	OpenedAt time.Time `gorm:"type:timestamp;not null"`
+
+	Bids []TenderOpeningBid `gorm:"foreignKey:OpeningID;references:Id;"`
+}
+
+func (TenderOpening) TableName() string {
+	return TenderOpeningName
+}
+
+const TenderOpeningBidName = "tender_opening_bid"
+
+// TenderOpeningBid is copy of bid contents at opening, later edits dont change protocol
+type TenderOpeningBid struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;" copier:"-"`
+
+	OpeningID uuid.UUID `gorm:"type:uuid;not null;index"`
+
+	BidID       uuid.UUID `gorm:"type:uuid;not null"`
+	Name        string    `gorm:"type:varchar(100);not null"`
+	Description string    `gorm:"type:varchar(500);"`
+	AuthorType  string    `gorm:"type:varchar(20);not null"`
+	AuthorID    uuid.UUID `gorm:"type:uuid;not null"`
+	Version     int       `gorm:"type:bigint"`
+}
+
+func (TenderOpeningBid) TableName() string {
+	return TenderOpeningBidName
+}
+
+const TenderOpeningVoteName = "tender_opening_vote"
+
+type TenderOpeningVote struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	TenderID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_opening_vote"`
+	Tender   Tender    `gorm:"foreignKey:TenderID;references:Id;" copier:"-"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_opening_vote"`
+	User   User      `gorm:"foreignKey:UserID;references:Id;" copier:"-"`
+
+	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (TenderOpeningVote) TableName() string {
+	return TenderOpeningVoteName
+}
@@ -53,18 +53,149 @@ func (Organization) TableName() string {
 	return OrganizationName
 }
 
+type OrganizationRoleType string
+
+const (
+	RoleOwner         OrganizationRoleType = "Owner"
+	RoleTenderManager OrganizationRoleType = "TenderManager"
+	RoleEvaluator     OrganizationRoleType = "Evaluator"
+	RoleViewer        OrganizationRoleType = "Viewer"
+)
+
+var OrganizationRoleTypeList = []OrganizationRoleType{RoleOwner, RoleTenderManager, RoleEvaluator, RoleViewer}
+
+func (r *OrganizationRoleType) Scan(value any) error {
+	strValue, ok := value.(string)
+	if !ok {
+		return errors.New("not string organization_role_type value")
+	}
+
+	*r = OrganizationRoleType(strValue)
+	return nil
+}
+
+func (r OrganizationRoleType) Value() (driver.Value, error) {
+	for _, validType := range OrganizationRoleTypeList {
+		if r == validType {
+			return string(r), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid organization_role_type value: %s", r)
+}
+
 const OrganizationResponsibleName = "organization_responsible"
 
 type OrganizationResponsible struct {
 	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
 
-	OrganizationID uuid.UUID    `gorm:"type:uuid;not null"`
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_responsible_org_user"`
 	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;constraint:OnDelete:CASCADE;"`
 
-	UserID uuid.UUID `gorm:"type:uuid;not null"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_responsible_org_user"`
 	User   User      `gorm:"foreignKey:UserID;references:Id;constraint:OnDelete:CASCADE;"`
+
+	// responsibles created before roles keep full power
+	Role OrganizationRoleType `gorm:"type:organization_role_type;default:'Owner';not null"`
 }
 
 func (OrganizationResponsible) TableName() string {
 	return OrganizationResponsibleName
 }
+
+type InvitationStatusType string
+
+const (
+	InvitationPending  InvitationStatusType = "Pending"
+	InvitationAccepted InvitationStatusType = "Accepted"
+	InvitationDeclined InvitationStatusType = "Declined"
+	InvitationRevoked  InvitationStatusType = "Revoked"
+)
+
+var InvitationStatusTypeList = []InvitationStatusType{InvitationPending, InvitationAccepted, InvitationDeclined, InvitationRevoked}
+
+func (s *InvitationStatusType) Scan(value any) error {
+	strValue, ok := value.(string)
+	if !ok {
+		return errors.New("not string invitation_status_type value")
+	}
+
+	*s = InvitationStatusType(strValue)
+	return nil
+}
+
+func (s InvitationStatusType) Value() (driver.Value, error) {
+	for _, validType := range InvitationStatusTypeList {
+		if s == validType {
+			return string(s), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid invitation_status_type value: %s", s)
+}
+
+const OrganizationInvitationName = "organization_invitation"
+
+type OrganizationInvitation struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	// user has at most one pending invitation to organization
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;index:idx_invitation_pending,unique,where:status = 'Pending'"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index:idx_invitation_pending,unique,where:status = 'Pending'"`
+	User   User      `gorm:"foreignKey:UserID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	InviterID uuid.UUID            `gorm:"type:uuid;not null"`
+	Role      OrganizationRoleType `gorm:"type:organization_role_type;not null"`
+
+	Status    InvitationStatusType `gorm:"type:invitation_status_type;not null"`
+	CreatedAt time.Time            `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time            `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (OrganizationInvitation) TableName() string {
+	return OrganizationInvitationName
+}
+
+const OrganizationAuditName = "organization_audit"
+
+type OrganizationAudit struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	ActorType    string     `gorm:"type:varchar(10);default:'User';not null"`
+	ActorID      uuid.UUID  `gorm:"type:uuid;not null"`
+	Action       string     `gorm:"type:varchar(50);not null"`
+	TargetUserID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (OrganizationAudit) TableName() string {
+	return OrganizationAuditName
+}
+
+const OrganizationReviewName = "organization_review"
+
+type OrganizationReview struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	// one review per bid
+	BidID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Bid   Bid       `gorm:"foreignKey:BidID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	PaymentDiscipline   int       `gorm:"type:smallint;not null"`
+	RequirementsClarity int       `gorm:"type:smallint;not null"`
+	Fairness            int       `gorm:"type:smallint;not null"`
+	Comment             string    `gorm:"type:varchar(1000)"`
+	ActorType           string    `gorm:"type:varchar(10);not null"`
+	ActorID             uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt           time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (OrganizationReview) TableName() string {
+	return OrganizationReviewName
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const TenderQuestionName = "tender_question"
+
+type TenderQuestion struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	TenderID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Tender   Tender    `gorm:"foreignKey:TenderID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	AuthorID uuid.UUID `gorm:"type:uuid;not null"`
+	Author   User      `gorm:"foreignKey:AuthorID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	Question   string     `gorm:"type:varchar(1000);not null"`
+	Answer     *string    `gorm:"type:varchar(2000)"`
+	AnswererID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	AnsweredAt *time.Time `gorm:"type:timestamp"`
+}
+
+func (TenderQuestion) TableName() string {
+	return TenderQuestionName
+}
@@ -79,8 +79,18 @@ type Tender struct {
 	OrganizationID uuid.UUID    `gorm:"type:uuid;not null"`
 	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;" copier:"-"`
 
-	Version   int       `gorm:"type:bigint"`
-	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	Version   int        `gorm:"type:bigint"`
+	CreatedAt time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	Deadline  *time.Time `gorm:"type:timestamp"`
+	Sealed    bool       `gorm:"not null;default:false"`
+	Anonymous bool       `gorm:"not null;default:false"`
+	// not versioned, rollback cant hide opened bids
+	OpenedAt *time.Time `gorm:"type:timestamp"`
+
+	AttachmentIDs UUIDList `gorm:"type:jsonb;not null;default:'[]'"`
+
+	ActorType string     `gorm:"type:varchar(10)"`
+	ActorID   *uuid.UUID `gorm:"type:uuid"`
 }
 
 func (Tender) TableName() string {
@@ -103,8 +113,16 @@ type TenderVersion struct {
 	OrganizationID uuid.UUID    `gorm:"type:uuid;not null"`
 	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;" copier:"-"`
 
-	Version   int       `gorm:"type:bigint"`
-	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	Version   int        `gorm:"type:bigint"`
+	CreatedAt time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	Deadline  *time.Time `gorm:"type:timestamp"`
+	Sealed    bool       `gorm:"not null;default:false"`
+	Anonymous bool       `gorm:"not null;default:false"`
+
+	AttachmentIDs UUIDList `gorm:"type:jsonb;not null;default:'[]'"`
+
+	ActorType string     `gorm:"type:varchar(10)"`
+	ActorID   *uuid.UUID `gorm:"type:uuid"`
 }
 
 func (TenderVersion) TableName() string {
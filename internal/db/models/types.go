@@ -0,0 +1,90 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// StringList is stored as jsonb array
+type StringList []string
+
+func (l *StringList) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("not json string_list value")
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// UUIDList is stored as jsonb array
+type UUIDList []uuid.UUID
+
+func (l *UUIDList) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("not json uuid_list value")
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+func (l UUIDList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// RawJSON is stored as jsonb as is
+type RawJSON []byte
+
+func (j *RawJSON) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*j = append(RawJSON{}, v...)
+	case string:
+		*j = RawJSON(v)
+	default:
+		return errors.New("not json raw_json value")
+	}
+
+	return nil
+}
+
+func (j RawJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return "null", nil
+	}
+	return string(j), nil
+}
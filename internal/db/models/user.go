@@ -15,6 +15,12 @@ type User struct {
 	LastName  string    `gorm:"type:varchar(50)"`
 	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
 	UpdatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+
+	Email  string `gorm:"type:varchar(255)"`
+	Locale string `gorm:"type:varchar(10)"`
+
+	PasswordHash string `gorm:"type:varchar(100)"`
+	Active       bool   `gorm:"default:true;not null"`
 }
 
 func (User) TableName() string {
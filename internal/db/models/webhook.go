@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const OutboxEventName = "outbox_event"
+
+type OutboxEvent struct {
+	Id              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+	Seq             int64      `gorm:"type:bigserial;autoIncrement;uniqueIndex"`
+	Type            string     `gorm:"type:varchar(50);not null"`
+	OrganizationIDs UUIDList   `gorm:"type:jsonb;not null"`
+	Payload         RawJSON    `gorm:"type:jsonb;not null"`
+	CreatedAt       time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	ProcessedAt     *time.Time `gorm:"type:timestamp;index"`
+	Attempts        int        `gorm:"not null;default:0"`
+	LastError       string     `gorm:"type:varchar(500)"`
+	LockedUntil     *time.Time `gorm:"type:timestamp"`
+}
+
+func (OutboxEvent) TableName() string {
+	return OutboxEventName
+}
+
+const EventHandledName = "event_handled"
+
+// EventHandled mark that subscriber already handled event, it is written in handler transaction
+type EventHandled struct {
+	EventID   uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	Event     OutboxEvent `gorm:"foreignKey:EventID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+	Handler   string      `gorm:"type:varchar(100);primaryKey"`
+	HandledAt time.Time   `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (EventHandled) TableName() string {
+	return EventHandledName
+}
+
+const WebhookName = "webhook"
+
+type Webhook struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	URL    string     `gorm:"type:varchar(500);not null"`
+	Events StringList `gorm:"type:jsonb;not null"`
+	Secret string     `gorm:"type:varchar(100);not null"`
+
+	CreatorID uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (Webhook) TableName() string {
+	return WebhookName
+}
+
+type DeliveryStatusType string
+
+const (
+	DeliveryPending   DeliveryStatusType = "Pending"
+	DeliverySucceeded DeliveryStatusType = "Succeeded"
+	DeliveryFailed    DeliveryStatusType = "Failed"
+)
+
+var deliveryStatusTypeList = []DeliveryStatusType{DeliveryPending, DeliverySucceeded, DeliveryFailed}
+
+func (s *DeliveryStatusType) Scan(value any) error {
+	strValue, ok := value.(string)
+	if !ok {
+		return errors.New("not string delivery_status_type value")
+	}
+
+	*s = DeliveryStatusType(strValue)
+	return nil
+}
+
+func (s DeliveryStatusType) Value() (driver.Value, error) {
+	for _, validType := range deliveryStatusTypeList {
+		if s == validType {
+			return string(s), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid delivery_status_type value: %s", s)
+}
+
+const WebhookDeliveryName = "webhook_delivery"
+
+type WebhookDelivery struct {
+	Id uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;"`
+
+	WebhookID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Webhook   Webhook   `gorm:"foreignKey:WebhookID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	EventID uuid.UUID   `gorm:"type:uuid;not null"`
+	Event   OutboxEvent `gorm:"foreignKey:EventID;references:Id;constraint:OnDelete:CASCADE;" copier:"-"`
+
+	EventType      string             `gorm:"type:varchar(50);not null"`
+	Status         DeliveryStatusType `gorm:"type:delivery_status_type;not null"`
+	Attempts       int                `gorm:"not null;default:0"`
+	ResponseStatus *int
+	LastError      string     `gorm:"type:varchar(500)"`
+	NextAttemptAt  *time.Time `gorm:"type:timestamp;index"`
+	CreatedAt      time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return WebhookDeliveryName
+}
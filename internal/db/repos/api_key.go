@@ -0,0 +1,107 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepo struct {
+	db *gorm.DB
+}
+
+func (r *ApiKeyRepo) GetClear() *gorm.DB { return r.db }
+
+func NewApiKeyRepo(cfg *config.DB) (*ApiKeyRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &ApiKeyRepo{
+		db: db,
+	}, nil
+}
+
+func (r *ApiKeyRepo) CreateApiKey(ctx context.Context, key *entity.ApiKey) (*entity.ApiKey, error) {
+	keyDB := utils.MustTransformObj[entity.ApiKey, models.ApiKey](key)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createRecord(ctx, tx, &models.ApiKey{}, keyDB); err != nil {
+			return fmt.Errorf("create api key: %w", err)
+		}
+
+		return createAudit(ctx, tx, keyDB.OrganizationID, keyDB.CreatorID, entity.AuditApiKeyCreated, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformObj[models.ApiKey, entity.ApiKey](keyDB), nil
+}
+
+func (r *ApiKeyRepo) GetApiKeyByID(ctx context.Context, id uuid.UUID) (*entity.ApiKey, error) {
+	return getSingleMappedRecord[entity.ApiKey, models.ApiKey](ctx, r.db, entity.ErrApiKeyNotFound, WithWhere("id = ?", id))
+}
+
+func (r *ApiKeyRepo) GetApiKeyByPrefix(ctx context.Context, prefix string) (*entity.ApiKey, error) {
+	return getSingleMappedRecord[entity.ApiKey, models.ApiKey](ctx, r.db, entity.ErrApiKeyNotFound, WithWhere("prefix = ?", prefix))
+}
+
+func (r *ApiKeyRepo) GetApiKeysPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.ApiKey], error) {
+	return getPageMappedRecord[entity.ApiKey, models.ApiKey](ctx, r.db, sort, pag, filters...)
+}
+
+func (r *ApiKeyRepo) RevokeApiKey(ctx context.Context, id uuid.UUID, actorID uuid.UUID) (*entity.ApiKey, error) {
+	var keyDB *models.ApiKey
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		queryRes := tx.WithContext(ctx).
+			Model(&models.ApiKey{}).
+			Where("id = ?", id).
+			Where("revoked_at IS NULL").
+			Update("revoked_at", time.Now())
+		if queryRes.Error != nil {
+			return queryRes.Error
+		}
+		if queryRes.RowsAffected == 0 {
+			return entity.ErrApiKeyRevoked
+		}
+
+		var err error
+		keyDB, err = getSingleRecord(ctx, tx, &models.ApiKey{}, WithWhere("id = ?", id))
+		if err != nil {
+			return err
+		}
+
+		return createAudit(ctx, tx, keyDB.OrganizationID, actorID, entity.AuditApiKeyRevoked, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformObj[models.ApiKey, entity.ApiKey](keyDB), nil
+}
+
+func (r *ApiKeyRepo) TouchApiKey(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ApiKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", time.Now()).
+		Error
+}
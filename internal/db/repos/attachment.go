@@ -0,0 +1,56 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepo struct {
+	db *gorm.DB
+}
+
+func (r *AttachmentRepo) GetClear() *gorm.DB { return r.db }
+
+func NewAttachmentRepo(cfg *config.DB) (*AttachmentRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &AttachmentRepo{
+		db: db,
+	}, nil
+}
+
+func (r *AttachmentRepo) CreateAttachment(ctx context.Context, attachment *entity.Attachment) (*entity.Attachment, error) {
+	attachmentDB := utils.MustTransformObj[entity.Attachment, models.Attachment](attachment)
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.Attachment{}, attachmentDB); err != nil {
+		return nil, fmt.Errorf("create attachment: %w", err)
+	}
+
+	return utils.MustTransformObj[models.Attachment, entity.Attachment](attachmentDB), nil
+}
+
+func (r *AttachmentRepo) GetAttachmentByID(ctx context.Context, id uuid.UUID) (*entity.Attachment, error) {
+	return getSingleMappedRecord[entity.Attachment, models.Attachment](ctx, conn(ctx, r.db), entity.ErrAttachmentNotFound, WithWhere("id = ?", id))
+}
+
+func (r *AttachmentRepo) GetAttachmentsByFilter(ctx context.Context, filters ...FilterOption) ([]entity.Attachment, error) {
+	return getMultiMappedRecord[entity.Attachment, models.Attachment](ctx, conn(ctx, r.db), filters...)
+}
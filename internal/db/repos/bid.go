@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
@@ -41,7 +43,7 @@ func NewBidRepo(cfg *config.DB) (*BidRepo, error) {
 func (r *BidRepo) createBackup(ctx context.Context, bid *models.Bid) error {
 	backup := trnsfrm.BidToBidVersion(bid)
 
-	err := createRecord(ctx, r.db, &models.BidVersion{}, backup)
+	err := createRecord(ctx, conn(ctx, r.db), &models.BidVersion{}, backup)
 
 	return err
 }
@@ -49,7 +51,7 @@ func (r *BidRepo) createBackup(ctx context.Context, bid *models.Bid) error {
 func (r *BidRepo) CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bid, error) {
 	bidDB := utils.MustTransformObj[entity.Bid, models.Bid](bid)
 
-	if err := createRecord(ctx, r.db, &models.Bid{}, bidDB); err != nil {
+	if err := createRecord(ctx, conn(ctx, r.db), &models.Bid{}, bidDB); err != nil {
 		return nil, fmt.Errorf("create bid: %w", err)
 	}
 
@@ -61,15 +63,23 @@ func (r *BidRepo) CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bid,
 }
 
 func (r *BidRepo) GetBidsByFilter(ctx context.Context, filters ...FilterOption) ([]entity.Bid, error) {
-	return getMultiMappedRecord[entity.Bid, models.Bid](ctx, r.db, filters...)
+	return getMultiMappedRecord[entity.Bid, models.Bid](ctx, conn(ctx, r.db), filters...)
+}
+
+func (r *BidRepo) GetBidsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.Bid], error) {
+	return getPageMappedRecord[entity.Bid, models.Bid](ctx, conn(ctx, r.db), sort, pag, filters...)
+}
+
+func (r *BidRepo) StreamBids(ctx context.Context, sort []entity.SortField, fn func(*entity.Bid) error, filters ...FilterOption) error {
+	return streamMappedRecord[entity.Bid, models.Bid](ctx, conn(ctx, r.db), sort, fn, filters...)
 }
 
 func (r *BidRepo) GetBidByID(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error) {
-	return getSingleMappedRecord[entity.Bid, models.Bid](ctx, r.db, entity.ErrBidNotFound, WithWhere("id = ?", bidID))
+	return getSingleMappedRecord[entity.Bid, models.Bid](ctx, conn(ctx, r.db), entity.ErrBidNotFound, WithWhere("id = ?", bidID))
 }
 
 func (r *BidRepo) UpdateBidStatus(ctx context.Context, bidID uuid.UUID, newStatus entity.BidStatusType) error {
-	queryRes := r.db.WithContext(ctx).
+	queryRes := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Bid{}).
 		Where("id = ?", bidID).
 		Update("status", newStatus)
@@ -85,10 +95,70 @@ func (r *BidRepo) UpdateBidStatus(ctx context.Context, bidID uuid.UUID, newStatu
 	return nil
 }
 
+func (r *BidRepo) SetBidDecision(ctx context.Context, bidID uuid.UUID, decision entity.BidDecisionType) error {
+	queryRes := conn(ctx, r.db).WithContext(ctx).
+		Model(&models.Bid{}).
+		Where("id = ?", bidID).
+		Update("decision", decision)
+
+	if queryRes.Error != nil {
+		return queryRes.Error
+	}
+
+	if queryRes.RowsAffected == 0 {
+		return entity.ErrBidNotFound
+	}
+
+	return nil
+}
+
+// TransitBid update bid fields as new version, zero values are written unlike PatchBid
+func (r *BidRepo) TransitBid(ctx context.Context, bidID uuid.UUID, fields map[string]any, actor entity.ActorRef) (*entity.Bid, error) {
+	fields["version"] = gorm.Expr("version + 1")
+	fields["actor_type"] = string(actor.Type)
+	fields["actor_id"] = actor.ID
+
+	queryRes := conn(ctx, r.db).WithContext(ctx).
+		Model(&models.Bid{}).
+		Where("id = ?", bidID).
+		Updates(fields)
+	if queryRes.Error != nil {
+		return nil, queryRes.Error
+	}
+	if queryRes.RowsAffected == 0 {
+		return nil, entity.ErrBidNotFound
+	}
+
+	bidDB, err := getSingleRecord(ctx, conn(ctx, r.db), &models.Bid{}, WithWhere("id = ?", bidID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.createBackup(ctx, bidDB); err != nil {
+		return nil, fmt.Errorf("create bid backup: %w", err)
+	}
+
+	return utils.MustTransformObj[models.Bid, entity.Bid](bidDB), nil
+}
+
+// GetBidVersionsPage get saved versions of bid, id of versions is bid id
+func (r *BidRepo) GetBidVersionsPage(ctx context.Context, bidID uuid.UUID, sort []entity.SortField, pag entity.Pagination) (*entity.Page[entity.Bid], error) {
+	page, err := getPageMappedRecord[entity.Bid, models.BidVersion](ctx, conn(ctx, r.db), sort, pag, WithWhere("bid_id = ?", bidID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range page.Items {
+		page.Items[i].Id = bidID
+	}
+
+	return page, nil
+}
+
 func (r *BidRepo) PatchBid(ctx context.Context, bidID uuid.UUID, patchBid *entity.Bid) (*entity.Bid, error) {
 	bidDB := utils.MustTransformObj[entity.Bid, models.Bid](patchBid)
 
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Bid{}).
 		Where("id = ?", bidID).
 		Updates(bidDB).
@@ -96,7 +166,7 @@ func (r *BidRepo) PatchBid(ctx context.Context, bidID uuid.UUID, patchBid *entit
 		return nil, err
 	}
 
-	bidDB, err := getSingleRecord(ctx, r.db, &models.Bid{}, WithWhere("id = ?", bidID))
+	bidDB, err := getSingleRecord(ctx, conn(ctx, r.db), &models.Bid{}, WithWhere("id = ?", bidID))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrBidNotFound
@@ -105,7 +175,7 @@ func (r *BidRepo) PatchBid(ctx context.Context, bidID uuid.UUID, patchBid *entit
 	}
 	bidDB.Version += 1
 
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Bid{}).
 		Where("id = ?", bidID).
 		Update("version", bidDB.Version).
@@ -123,15 +193,59 @@ func (r *BidRepo) PatchBid(ctx context.Context, bidID uuid.UUID, patchBid *entit
 func (r *BidRepo) CreateFeedback(ctx context.Context, feedback *entity.BidRewiew) (*entity.BidRewiew, error) {
 	rewiewDB := utils.MustTransformObj[entity.BidRewiew, models.BidRewiew](feedback)
 
-	if err := createRecord(ctx, r.db, &models.BidRewiew{}, rewiewDB); err != nil {
+	if err := createRecord(ctx, conn(ctx, r.db), &models.BidRewiew{}, rewiewDB); err != nil {
+		if isUniqueViolation(err) {
+			return nil, entity.ErrReviewExists
+		}
 		return nil, fmt.Errorf("create rewiew: %w", err)
 	}
 
 	return utils.MustTransformObj[models.BidRewiew, entity.BidRewiew](rewiewDB), nil
 }
 
-func (r *BidRepo) RollbackBid(ctx context.Context, bidID uuid.UUID, version int) (*entity.Bid, error) {
-	currBid, err := getSingleRecord(ctx, r.db, &models.Bid{}, WithWhere("id = ?", bidID))
+// GetFeedbackByID get not deleted review, hidden by moderation too
+func (r *BidRepo) GetFeedbackByID(ctx context.Context, id uuid.UUID) (*entity.BidRewiew, error) {
+	return getSingleMappedRecord[entity.BidRewiew, models.BidRewiew](ctx, conn(ctx, r.db), entity.ErrReviewNotFound,
+		WithWhere("id = ?", id),
+		WithWhere("deleted_at IS NULL"),
+	)
+}
+
+func (r *BidRepo) UpdateFeedback(ctx context.Context, id uuid.UUID, fields map[string]any) (*entity.BidRewiew, error) {
+	queryRes := conn(ctx, r.db).WithContext(ctx).
+		Model(&models.BidRewiew{}).
+		Where("id = ?", id).
+		Where("deleted_at IS NULL").
+		Updates(fields)
+	if queryRes.Error != nil {
+		return nil, queryRes.Error
+	}
+	if queryRes.RowsAffected == 0 {
+		return nil, entity.ErrReviewNotFound
+	}
+
+	return r.GetFeedbackByID(ctx, id)
+}
+
+func (r *BidRepo) CreateFeedbackVersion(ctx context.Context, version *entity.ReviewVersion) error {
+	versionDB := utils.MustTransformObj[entity.ReviewVersion, models.ReviewVersion](version)
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.ReviewVersion{}, versionDB); err != nil {
+		return fmt.Errorf("create rewiew version: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BidRepo) GetFeedbackVersions(ctx context.Context, reviewID uuid.UUID) ([]entity.ReviewVersion, error) {
+	return getMultiMappedRecord[entity.ReviewVersion, models.ReviewVersion](ctx, conn(ctx, r.db),
+		WithWhere("review_id = ?", reviewID),
+		WithOrder("edited_at asc"),
+	)
+}
+
+func (r *BidRepo) RollbackBid(ctx context.Context, bidID uuid.UUID, version int, actor entity.ActorRef) (*entity.Bid, error) {
+	currBid, err := getSingleRecord(ctx, conn(ctx, r.db), &models.Bid{}, WithWhere("id = ?", bidID))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrBidNotFound
@@ -143,7 +257,7 @@ func (r *BidRepo) RollbackBid(ctx context.Context, bidID uuid.UUID, version int)
 	newShips := currBid.ShipsCount
 	newVersion := currBid.Version + 1
 
-	backupBid, err := getSingleRecord(ctx, r.db, &models.BidVersion{},
+	backupBid, err := getSingleRecord(ctx, conn(ctx, r.db), &models.BidVersion{},
 		WithWhere("bid_id = ?", bidID),
 		WithWhere("version = ?", version),
 	)
@@ -158,8 +272,13 @@ func (r *BidRepo) RollbackBid(ctx context.Context, bidID uuid.UUID, version int)
 	rollbackBid.Status = newStatus
 	rollbackBid.ShipsCount = newShips
 	rollbackBid.Version = newVersion
+	// withdrawal state is not rolled back, otherwise resubmission limit could be reset
+	rollbackBid.WithdrawReason = currBid.WithdrawReason
+	rollbackBid.Resubmissions = currBid.Resubmissions
+	rollbackBid.ActorType = string(actor.Type)
+	rollbackBid.ActorID = &actor.ID
 
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Bid{}).
 		Where("id = ?", bidID).
 		Updates(rollbackBid).
@@ -175,11 +294,72 @@ func (r *BidRepo) RollbackBid(ctx context.Context, bidID uuid.UUID, version int)
 }
 
 func (r *BidRepo) GetFeedbacksByFilter(ctx context.Context, filters ...FilterOption) ([]entity.BidRewiew, error) {
-	return getMultiMappedRecord[entity.BidRewiew, models.BidRewiew](ctx, r.db, filters...)
+	return getMultiMappedRecord[entity.BidRewiew, models.BidRewiew](ctx, conn(ctx, r.db), filters...)
+}
+
+// GetReputation aggregate ratings of reviews to bids of author,
+// review score is average of its rated dimensions, not rated reviews are only counted
+func (r *BidRepo) GetReputation(ctx context.Context, authorType entity.BidAuthorType, authorID uuid.UUID, recentSince time.Time) (*entity.Reputation, error) {
+	reviews := r.db.WithContext(ctx).
+		Table(models.BidRewiewName+" AS r").
+		Select("r.*, (SELECT AVG(v) FROM (VALUES (r.quality), (r.timeliness), (r.communication)) AS s(v)) AS score").
+		Joins("JOIN "+models.BidName+" AS b ON b.id = r.bid_id").
+		Where("b.author_type = ?", authorType).
+		Where("b.author_id = ?", authorID).
+		Where("r.deleted_at IS NULL").
+		Where("r.moderation_status = ?", entity.ReviewVisible)
+
+	var res struct {
+		Count           int64
+		Average         *float64
+		Quality         *float64
+		Timeliness      *float64
+		Communication   *float64
+		RecentAverage   *float64
+		PreviousAverage *float64
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("(?) AS t", reviews).
+		Select(`COUNT(*) AS count,
+			ROUND(AVG(score), 2) AS average,
+			ROUND(AVG(quality), 2) AS quality,
+			ROUND(AVG(timeliness), 2) AS timeliness,
+			ROUND(AVG(communication), 2) AS communication,
+			ROUND(AVG(score) FILTER (WHERE created_at >= ?), 2) AS recent_average,
+			ROUND(AVG(score) FILTER (WHERE created_at < ?), 2) AS previous_average`, recentSince, recentSince).
+		Scan(&res).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	reputation := entity.Reputation{
+		Count:         res.Count,
+		Average:       res.Average,
+		Quality:       res.Quality,
+		Timeliness:    res.Timeliness,
+		Communication: res.Communication,
+		RecentAverage: res.RecentAverage,
+	}
+	if res.RecentAverage != nil && res.PreviousAverage != nil {
+		trend := math.Round((*res.RecentAverage-*res.PreviousAverage)*100) / 100
+		reputation.Trend = &trend
+	}
+
+	return &reputation, nil
+}
+
+func (r *BidRepo) GetFeedbacksPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.BidRewiew], error) {
+	return getPageMappedRecord[entity.BidRewiew, models.BidRewiew](ctx, conn(ctx, r.db), sort, pag, filters...)
+}
+
+func (r *BidRepo) StreamFeedbacks(ctx context.Context, sort []entity.SortField, fn func(*entity.BidRewiew) error, filters ...FilterOption) error {
+	return streamMappedRecord[entity.BidRewiew, models.BidRewiew](ctx, conn(ctx, r.db), sort, fn, filters...)
 }
 
 func (r *BidRepo) ShipBid(ctx context.Context, userID uuid.UUID, bidID uuid.UUID) (bool, error) {
-	_, err := getSingleRecord(ctx, r.db, &models.BidShip{},
+	_, err := getSingleRecord(ctx, conn(ctx, r.db), &models.BidShip{},
 		WithWhere("user_id = ?", userID),
 		WithWhere("bid_id = ?", bidID),
 	)
@@ -187,7 +367,7 @@ func (r *BidRepo) ShipBid(ctx context.Context, userID uuid.UUID, bidID uuid.UUID
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return false, err
 		}
-		if err := createRecord(ctx, r.db, &models.BidShip{}, &models.BidShip{UserID: userID, BidID: bidID}); err != nil {
+		if err := createRecord(ctx, conn(ctx, r.db), &models.BidShip{}, &models.BidShip{UserID: userID, BidID: bidID}); err != nil {
 			return false, err
 		}
 
@@ -197,7 +377,7 @@ func (r *BidRepo) ShipBid(ctx context.Context, userID uuid.UUID, bidID uuid.UUID
 		}
 
 		bid.ShipsCount += 1
-		r.db.WithContext(ctx).Model(&models.Bid{}).Where("id = ?", bid.Id).Update("ships_count", bid.ShipsCount)
+		conn(ctx, r.db).WithContext(ctx).Model(&models.Bid{}).Where("id = ?", bid.Id).Update("ships_count", bid.ShipsCount)
 
 		return true, nil
 	}
@@ -205,7 +385,7 @@ func (r *BidRepo) ShipBid(ctx context.Context, userID uuid.UUID, bidID uuid.UUID
 }
 
 func (r *BidRepo) UnshipsBid(ctx context.Context, bidID uuid.UUID) error {
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.BidShip{}).
 		Where("bid_id = ?", bidID).
 		Delete(&models.BidShip{}).
@@ -213,7 +393,7 @@ func (r *BidRepo) UnshipsBid(ctx context.Context, bidID uuid.UUID) error {
 		return err
 	}
 
-	err := r.db.WithContext(ctx).Model(&models.Bid{}).Where("id = ?", bidID).Update("ships_count", 0).Error
+	err := conn(ctx, r.db).WithContext(ctx).Model(&models.Bid{}).Where("id = ?", bidID).Update("ships_count", 0).Error
 
 	return err
 }
@@ -0,0 +1,112 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailRepo struct {
+	db *gorm.DB
+}
+
+func (r *EmailRepo) GetClear() *gorm.DB { return r.db }
+
+func NewEmailRepo(cfg *config.DB) (*EmailRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &EmailRepo{
+		db: db,
+	}, nil
+}
+
+// CreateEmails queue emails skipping already queued for the same event
+func (r *EmailRepo) CreateEmails(ctx context.Context, emails []entity.Email) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	emailsDB := utils.MustTransformSlice[entity.Email, models.Email](emails)
+	for i := range emailsDB {
+		emailsDB[i].Status = models.DeliveryPending
+		emailsDB[i].NextAttemptAt = &now
+	}
+
+	return conn(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&emailsDB).
+		Error
+}
+
+// ClaimEmails take due pending emails and hide them from other workers for lease
+func (r *EmailRepo) ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]entity.Email, error) {
+	var emails []models.Email
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		emails, err = getMultiRecord(ctx, tx, &models.Email{},
+			WithWhere("status = ?", models.DeliveryPending),
+			WithWhere("next_attempt_at <= ?", time.Now()),
+			WithOrder("next_attempt_at asc"),
+			WithLimit(limit),
+			WithSkipLocked(),
+		)
+		if err != nil {
+			return fmt.Errorf("get emails: %w", err)
+		}
+		if len(emails) == 0 {
+			return nil
+		}
+
+		ids := []uuid.UUID{}
+		for _, email := range emails {
+			ids = append(ids, email.Id)
+		}
+
+		return tx.WithContext(ctx).
+			Model(&models.Email{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", time.Now().Add(lease)).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformSlice[models.Email, entity.Email](emails), nil
+}
+
+// SaveEmailAttempt store result of attempt
+func (r *EmailRepo) SaveEmailAttempt(ctx context.Context, email *entity.Email) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Email{}).
+		Where("id = ?", email.Id).
+		Updates(map[string]any{
+			"status":          models.DeliveryStatusType(email.Status),
+			"attempts":        email.Attempts,
+			"last_error":      email.LastError,
+			"next_attempt_at": email.NextAttemptAt,
+			"updated_at":      time.Now(),
+		}).
+		Error
+}
@@ -0,0 +1,63 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	outboxChannel  = "outbox_event"
+	reconnectDelay = time.Second
+)
+
+// EventListener wait for postgres notifications about new outbox events,
+// it keeps own connection because LISTEN is bound to session
+type EventListener struct {
+	dsn string
+}
+
+func NewEventListener(cfg *config.DB) *EventListener {
+	return &EventListener{
+		dsn: cfg.PostgresConn,
+	}
+}
+
+// Listen call notify on every committed outbox event until ctx is done,
+// notify is also called after (re)connect because notifications could be missed
+func (l *EventListener) Listen(ctx context.Context, notify func()) {
+	for ctx.Err() == nil {
+		if err := l.listen(ctx, notify); err != nil && ctx.Err() == nil {
+			log.Printf("listen %s: %v", outboxChannel, err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (l *EventListener) listen(ctx context.Context, notify func()) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+outboxChannel); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	notify()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return fmt.Errorf("wait notification: %w", err)
+		}
+		notify()
+	}
+}
@@ -0,0 +1,83 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type MessageRepo struct {
+	db *gorm.DB
+}
+
+func (r *MessageRepo) GetClear() *gorm.DB { return r.db }
+
+func NewMessageRepo(cfg *config.DB) (*MessageRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &MessageRepo{
+		db: db,
+	}, nil
+}
+
+func (r *MessageRepo) CreateMessage(ctx context.Context, message *entity.BidMessage) (*entity.BidMessage, error) {
+	messageDB := utils.MustTransformObj[entity.BidMessage, models.BidMessage](message)
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.BidMessage{}, messageDB); err != nil {
+		return nil, fmt.Errorf("create message: %w", err)
+	}
+
+	return utils.MustTransformObj[models.BidMessage, entity.BidMessage](messageDB), nil
+}
+
+func (r *MessageRepo) GetMessagesPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.BidMessage], error) {
+	return getPageMappedRecord[entity.BidMessage, models.BidMessage](ctx, conn(ctx, r.db), sort, pag, filters...)
+}
+
+// MarkRead mark unread messages of other side in bid thread, returns number of marked
+func (r *MessageRepo) MarkRead(ctx context.Context, bidID uuid.UUID, reader entity.MessageSide) (int64, error) {
+	queryRes := r.db.WithContext(ctx).
+		Model(&models.BidMessage{}).
+		Where("bid_id = ?", bidID).
+		Where("side <> ?", reader).
+		Where("read_at IS NULL").
+		Update("read_at", time.Now())
+
+	return queryRes.RowsAffected, queryRes.Error
+}
+
+// CountUnread count messages of other side in bid thread not read by reader
+func (r *MessageRepo) CountUnread(ctx context.Context, bidID uuid.UUID, reader entity.MessageSide) (*entity.MessageCount, error) {
+	count := entity.MessageCount{}
+
+	err := r.db.WithContext(ctx).
+		Model(&models.BidMessage{}).
+		Where("bid_id = ?", bidID).
+		Where("side <> ?", reader).
+		Where("read_at IS NULL").
+		Count(&count.Unread).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
@@ -0,0 +1,157 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepo struct {
+	db *gorm.DB
+}
+
+func (r *NotificationRepo) GetClear() *gorm.DB { return r.db }
+
+func NewNotificationRepo(cfg *config.DB) (*NotificationRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &NotificationRepo{
+		db: db,
+	}, nil
+}
+
+// CreateNotifications create notifications skipping already created for the same event
+func (r *NotificationRepo) CreateNotifications(ctx context.Context, notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	notificationsDB := utils.MustTransformSlice[entity.Notification, models.Notification](notifications)
+
+	return conn(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&notificationsDB).
+		Error
+}
+
+func (r *NotificationRepo) GetNotificationsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.Notification], error) {
+	return getPageMappedRecord[entity.Notification, models.Notification](ctx, r.db, sort, pag, filters...)
+}
+
+func (r *NotificationRepo) CountNotifications(ctx context.Context, userID uuid.UUID) (*entity.NotificationCount, error) {
+	count := entity.NotificationCount{}
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE read_at IS NULL) AS unread").
+		Where("user_id = ?", userID).
+		Scan(&count).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+func (r *NotificationRepo) MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID) (*entity.Notification, error) {
+	queryRes := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("id = ?", notificationID).
+		Where("user_id = ?", userID).
+		Where("read_at IS NULL").
+		Update("read_at", time.Now())
+	if queryRes.Error != nil {
+		return nil, queryRes.Error
+	}
+
+	return getSingleMappedRecord[entity.Notification, models.Notification](ctx, r.db, entity.ErrNotificationNotFound,
+		WithWhere("id = ?", notificationID),
+		WithWhere("user_id = ?", userID),
+	)
+}
+
+// MarkAllRead mark all unread notifications of user, returns number of marked
+func (r *NotificationRepo) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	queryRes := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("user_id = ?", userID).
+		Where("read_at IS NULL").
+		Update("read_at", time.Now())
+
+	return queryRes.RowsAffected, queryRes.Error
+}
+
+// GetPreferences get preferences of user for all types, missing ones are enabled
+func (r *NotificationRepo) GetPreferences(ctx context.Context, userID uuid.UUID) ([]entity.NotificationPreference, error) {
+	prefsDB, err := getMultiRecord(ctx, r.db, &models.NotificationPreference{}, WithWhere("user_id = ?", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := map[string]bool{}
+	for _, pref := range prefsDB {
+		enabled[pref.Type] = pref.Enabled
+	}
+
+	prefs := []entity.NotificationPreference{}
+	for _, notificationType := range entity.NotificationTypeList {
+		isEnabled, ok := enabled[string(notificationType)]
+		prefs = append(prefs, entity.NotificationPreference{Type: notificationType, Enabled: !ok || isEnabled})
+	}
+
+	return prefs, nil
+}
+
+func (r *NotificationRepo) SetPreferences(ctx context.Context, userID uuid.UUID, prefs []entity.NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+
+	prefsDB := []models.NotificationPreference{}
+	for _, pref := range prefs {
+		prefsDB = append(prefsDB, models.NotificationPreference{UserID: userID, Type: string(pref.Type), Enabled: pref.Enabled})
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+		}).
+		Create(&prefsDB).
+		Error
+}
+
+// GetOptedOutUsers get users among given who disabled notifications of type
+func (r *NotificationRepo) GetOptedOutUsers(ctx context.Context, notificationType entity.NotificationType, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var optedOut []uuid.UUID
+
+	err := conn(ctx, r.db).WithContext(ctx).
+		Model(&models.NotificationPreference{}).
+		Where("type = ?", notificationType).
+		Where("enabled = ?", false).
+		Where("user_id IN ?", userIDs).
+		Pluck("user_id", &optedOut).
+		Error
+
+	return optedOut, err
+}
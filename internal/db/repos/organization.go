@@ -0,0 +1,383 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepo struct {
+	db *gorm.DB
+}
+
+func (r *OrganizationRepo) GetClear() *gorm.DB { return r.db }
+
+func NewOrganizationRepo(cfg *config.DB) (*OrganizationRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &OrganizationRepo{
+		db: db,
+	}, nil
+}
+
+// CreateOrganization create organization with creator as owner
+func (r *OrganizationRepo) CreateOrganization(ctx context.Context, org *entity.Organization, creatorID uuid.UUID) (*entity.Organization, error) {
+	orgDB := utils.MustTransformObj[entity.Organization, models.Organization](org)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createRecord(ctx, tx, &models.Organization{}, orgDB); err != nil {
+			return fmt.Errorf("create organization: %w", err)
+		}
+
+		if err := createRecord(ctx, tx, &models.OrganizationResponsible{}, &models.OrganizationResponsible{
+			OrganizationID: orgDB.Id,
+			UserID:         creatorID,
+			Role:           models.RoleOwner,
+		}); err != nil {
+			return fmt.Errorf("create organization responsible: %w", err)
+		}
+
+		return createAudit(ctx, tx, orgDB.Id, creatorID, entity.AuditOrganizationCreated, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformObj[models.Organization, entity.Organization](orgDB), nil
+}
+
+func (r *OrganizationRepo) GetOrgByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	return getSingleMappedRecord[entity.Organization, models.Organization](ctx, r.db, entity.ErrOrgNotFound, WithWhere("id = ?", id))
+}
+
+func (r *OrganizationRepo) GetOrgsByFilter(ctx context.Context, filters ...FilterOption) ([]entity.Organization, error) {
+	return getMultiMappedRecord[entity.Organization, models.Organization](ctx, r.db, filters...)
+}
+
+func (r *OrganizationRepo) GetOrgsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.Organization], error) {
+	return getPageMappedRecord[entity.Organization, models.Organization](ctx, r.db, sort, pag, filters...)
+}
+
+func (r *OrganizationRepo) PatchOrganization(ctx context.Context, orgID uuid.UUID, patchOrg *entity.Organization, actorID uuid.UUID) (*entity.Organization, error) {
+	orgDB := utils.MustTransformObj[entity.Organization, models.Organization](patchOrg)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.WithContext(ctx).
+			Model(&models.Organization{}).
+			Where("id = ?", orgID).
+			Updates(orgDB).
+			Error; err != nil {
+			return err
+		}
+
+		return createAudit(ctx, tx, orgID, actorID, entity.AuditOrganizationUpdated, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orgDB, err = getSingleRecord(ctx, r.db, &models.Organization{}, WithWhere("id = ?", orgID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrOrgNotFound
+		}
+		return nil, err
+	}
+
+	return utils.MustTransformObj[models.Organization, entity.Organization](orgDB), nil
+}
+
+// GetOrgMemberships get memberships of active users, deactivated ones cant act for organization
+func (r *OrganizationRepo) GetOrgMemberships(ctx context.Context, orgID uuid.UUID) ([]entity.Membership, error) {
+	return getMultiMappedRecord[entity.Membership, models.OrganizationResponsible](ctx, r.db,
+		WithWhere("organization_id = ?", orgID),
+		WithWhere("user_id IN (SELECT id FROM "+models.UserName+" WHERE active = ?)", true),
+	)
+}
+
+func (r *OrganizationRepo) GetUserMemberships(ctx context.Context, userID uuid.UUID) ([]entity.Membership, error) {
+	return getMultiMappedRecord[entity.Membership, models.OrganizationResponsible](ctx, r.db, WithWhere("user_id = ?", userID))
+}
+
+func (r *OrganizationRepo) GetUserOrgsUUIDs(ctx context.Context, userID uuid.UUID) (uuid.UUIDs, error) {
+	resp, err := getMultiRecord(ctx, r.db, &models.OrganizationResponsible{}, WithWhere("user_id = ?", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return trnsfrm.OrgRespToOrgUUIDSlice(resp), nil
+}
+
+func (r *OrganizationRepo) GetOrgResponsibles(ctx context.Context, orgID uuid.UUID) ([]entity.OrganizationMember, error) {
+	responsibles, err := getMultiRecord(ctx, r.db, &models.OrganizationResponsible{},
+		WithPreload("User"),
+		WithWhere("organization_id = ?", orgID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []entity.OrganizationMember{}
+	for _, resp := range responsibles {
+		members = append(members, entity.OrganizationMember{
+			User: *utils.MustTransformObj[models.User, entity.User](&resp.User),
+			Role: entity.OrganizationRole(resp.Role),
+		})
+	}
+	slices.SortFunc(members, func(a, b entity.OrganizationMember) int {
+		return strings.Compare(a.User.Username, b.User.Username)
+	})
+
+	return members, nil
+}
+
+func (r *OrganizationRepo) CreateInvitation(ctx context.Context, invitation *entity.OrganizationInvitation) (*entity.OrganizationInvitation, error) {
+	invitationDB := utils.MustTransformObj[entity.OrganizationInvitation, models.OrganizationInvitation](invitation)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createRecord(ctx, tx, &models.OrganizationInvitation{}, invitationDB); err != nil {
+			if isUniqueViolation(err) {
+				return entity.ErrInvitationExists
+			}
+			return fmt.Errorf("create invitation: %w", err)
+		}
+
+		return createAudit(ctx, tx, invitationDB.OrganizationID, invitationDB.InviterID, entity.AuditInvitationCreated, &invitationDB.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformObj[models.OrganizationInvitation, entity.OrganizationInvitation](invitationDB), nil
+}
+
+func (r *OrganizationRepo) GetInvitationByID(ctx context.Context, invitationID uuid.UUID) (*entity.OrganizationInvitation, error) {
+	return getSingleMappedRecord[entity.OrganizationInvitation, models.OrganizationInvitation](ctx, r.db, entity.ErrInvitationNotFound, WithWhere("id = ?", invitationID))
+}
+
+func (r *OrganizationRepo) GetInvitationsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.OrganizationInvitation], error) {
+	return getPageMappedRecord[entity.OrganizationInvitation, models.OrganizationInvitation](ctx, r.db, sort, pag, filters...)
+}
+
+// ResolveInvitation move pending invitation to new status, accepted invitation makes user responsible
+func (r *OrganizationRepo) ResolveInvitation(ctx context.Context, invitationID uuid.UUID, status entity.InvitationStatusType, actorID uuid.UUID) (*entity.OrganizationInvitation, error) {
+	var invitationDB *models.OrganizationInvitation
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		queryRes := tx.WithContext(ctx).
+			Model(&models.OrganizationInvitation{}).
+			Where("id = ?", invitationID).
+			Where("status = ?", entity.InvitationPending).
+			Updates(map[string]any{"status": status, "updated_at": time.Now()})
+		if queryRes.Error != nil {
+			return queryRes.Error
+		}
+		if queryRes.RowsAffected == 0 {
+			return entity.ErrInvitationNotPending
+		}
+
+		var err error
+		invitationDB, err = getSingleRecord(ctx, tx, &models.OrganizationInvitation{}, WithWhere("id = ?", invitationID))
+		if err != nil {
+			return err
+		}
+
+		action := map[entity.InvitationStatusType]entity.OrganizationAuditAction{
+			entity.InvitationAccepted: entity.AuditInvitationAccepted,
+			entity.InvitationDeclined: entity.AuditInvitationDeclined,
+			entity.InvitationRevoked:  entity.AuditInvitationRevoked,
+		}[status]
+
+		if status == entity.InvitationAccepted {
+			if err := createRecord(ctx, tx, &models.OrganizationResponsible{}, &models.OrganizationResponsible{
+				OrganizationID: invitationDB.OrganizationID,
+				UserID:         invitationDB.UserID,
+				Role:           invitationDB.Role,
+			}); err != nil {
+				if isUniqueViolation(err) {
+					return entity.ErrAlreadyResponsible
+				}
+				return fmt.Errorf("create organization responsible: %w", err)
+			}
+		}
+
+		return createAudit(ctx, tx, invitationDB.OrganizationID, actorID, action, &invitationDB.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformObj[models.OrganizationInvitation, entity.OrganizationInvitation](invitationDB), nil
+}
+
+// RemoveResponsible remove user from organization, the last responsible and the last owner cant be removed
+func (r *OrganizationRepo) RemoveResponsible(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, actorID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		responsibles, err := getMultiRecord(ctx, tx, &models.OrganizationResponsible{},
+			WithWhere("organization_id = ?", orgID),
+			WithLockForUpdate(),
+		)
+		if err != nil {
+			return err
+		}
+
+		target := slices.IndexFunc(responsibles, func(resp models.OrganizationResponsible) bool { return resp.UserID == userID })
+		if target == -1 {
+			return entity.ErrNotResponsible
+		}
+		if len(responsibles) <= 1 {
+			return entity.ErrLastResponsible
+		}
+		if responsibles[target].Role == models.RoleOwner && countOwners(responsibles) <= 1 {
+			return entity.ErrLastOwner
+		}
+
+		if err := tx.WithContext(ctx).
+			Where("organization_id = ?", orgID).
+			Where("user_id = ?", userID).
+			Delete(&models.OrganizationResponsible{}).
+			Error; err != nil {
+			return err
+		}
+
+		action := entity.AuditResponsibleRemoved
+		if actorID == userID {
+			action = entity.AuditResponsibleLeft
+		}
+
+		return createAudit(ctx, tx, orgID, actorID, action, &userID)
+	})
+}
+
+// SetResponsibleRole change role of organization responsible, the last owner cant be downgraded
+func (r *OrganizationRepo) SetResponsibleRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role entity.OrganizationRole, actorID uuid.UUID) (*entity.Membership, error) {
+	roleDB := models.OrganizationRoleType(role)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		responsibles, err := getMultiRecord(ctx, tx, &models.OrganizationResponsible{},
+			WithWhere("organization_id = ?", orgID),
+			WithLockForUpdate(),
+		)
+		if err != nil {
+			return err
+		}
+
+		target := slices.IndexFunc(responsibles, func(resp models.OrganizationResponsible) bool { return resp.UserID == userID })
+		if target == -1 {
+			return entity.ErrNotResponsible
+		}
+		if responsibles[target].Role == models.RoleOwner && roleDB != models.RoleOwner && countOwners(responsibles) <= 1 {
+			return entity.ErrLastOwner
+		}
+
+		if err := tx.WithContext(ctx).
+			Model(&models.OrganizationResponsible{}).
+			Where("organization_id = ?", orgID).
+			Where("user_id = ?", userID).
+			Update("role", roleDB).
+			Error; err != nil {
+			return err
+		}
+
+		return createAudit(ctx, tx, orgID, actorID, entity.AuditResponsibleRole, &userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Membership{OrganizationID: orgID, UserID: userID, Role: role}, nil
+}
+
+func (r *OrganizationRepo) GetAuditPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.OrganizationAudit], error) {
+	return getPageMappedRecord[entity.OrganizationAudit, models.OrganizationAudit](ctx, r.db, sort, pag, filters...)
+}
+
+func (r *OrganizationRepo) CreateOrgReview(ctx context.Context, review *entity.OrganizationReview) (*entity.OrganizationReview, error) {
+	reviewDB := utils.MustTransformObj[entity.OrganizationReview, models.OrganizationReview](review)
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.OrganizationReview{}, reviewDB); err != nil {
+		// concurrent review of same bid
+		if isUniqueViolation(err) {
+			return nil, entity.ErrOrgReviewExists
+		}
+		return nil, fmt.Errorf("create organization review: %w", err)
+	}
+
+	return utils.MustTransformObj[models.OrganizationReview, entity.OrganizationReview](reviewDB), nil
+}
+
+func (r *OrganizationRepo) GetOrgReviewsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.OrganizationReview], error) {
+	return getPageMappedRecord[entity.OrganizationReview, models.OrganizationReview](ctx, conn(ctx, r.db), sort, pag, filters...)
+}
+
+// GetOrgRatings aggregate reviews by organization, organizations without reviews are missing
+func (r *OrganizationRepo) GetOrgRatings(ctx context.Context, orgIDs uuid.UUIDs) (map[uuid.UUID]entity.OrganizationRating, error) {
+	var rows []struct {
+		OrganizationID uuid.UUID
+		entity.OrganizationRating
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&models.OrganizationReview{}).
+		Select(`organization_id,
+			COUNT(*) AS count,
+			ROUND(AVG((payment_discipline + requirements_clarity + fairness) / 3.0), 2) AS average,
+			ROUND(AVG(payment_discipline), 2) AS payment_discipline,
+			ROUND(AVG(requirements_clarity), 2) AS requirements_clarity,
+			ROUND(AVG(fairness), 2) AS fairness`).
+		Where("organization_id IN ?", orgIDs).
+		Group("organization_id").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := map[uuid.UUID]entity.OrganizationRating{}
+	for _, row := range rows {
+		ratings[row.OrganizationID] = row.OrganizationRating
+	}
+
+	return ratings, nil
+}
+
+func createAudit(ctx context.Context, db *gorm.DB, orgID uuid.UUID, actorID uuid.UUID, action entity.OrganizationAuditAction, targetUserID *uuid.UUID) error {
+	return createRecord(ctx, db, &models.OrganizationAudit{}, &models.OrganizationAudit{
+		OrganizationID: orgID,
+		ActorType:      string(entity.ActorUser),
+		ActorID:        actorID,
+		Action:         string(action),
+		TargetUserID:   targetUserID,
+	})
+}
+
+func countOwners(responsibles []models.OrganizationResponsible) int {
+	owners := 0
+	for _, resp := range responsibles {
+		if resp.Role == models.RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
@@ -0,0 +1,152 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+func (r *OutboxRepo) GetClear() *gorm.DB { return r.db }
+
+func NewOutboxRepo(cfg *config.DB) (*OutboxRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &OutboxRepo{
+		db: db,
+	}, nil
+}
+
+// AddEvent write event, must be called within transaction of change to not lose it
+func (r *OutboxRepo) AddEvent(ctx context.Context, event *entity.Event) (*entity.Event, error) {
+	eventDB := utils.MustTransformObj[entity.Event, models.OutboxEvent](event)
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.OutboxEvent{}, eventDB); err != nil {
+		return nil, fmt.Errorf("create outbox event: %w", err)
+	}
+
+	// notification is sent on commit, so listeners never see rolled back events
+	if err := conn(ctx, r.db).WithContext(ctx).Exec("SELECT pg_notify(?, '')", outboxChannel).Error; err != nil {
+		return nil, fmt.Errorf("notify: %w", err)
+	}
+
+	return utils.MustTransformObj[models.OutboxEvent, entity.Event](eventDB), nil
+}
+
+// GetEventsAfter get events with seq greater than given in seq order
+func (r *OutboxRepo) GetEventsAfter(ctx context.Context, seq int64, limit int) ([]entity.Event, error) {
+	return getMultiMappedRecord[entity.Event, models.OutboxEvent](ctx, r.db,
+		WithWhere("seq > ?", seq),
+		WithOrder("seq asc"),
+		WithLimit(limit),
+	)
+}
+
+func (r *OutboxRepo) GetLastEventSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Select("COALESCE(MAX(seq), 0)").
+		Scan(&seq).
+		Error
+
+	return seq, err
+}
+
+// ClaimEvents take unprocessed events and hide them from other workers for lease,
+// events failed maxAttempts times are left for manual investigation
+func (r *OutboxRepo) ClaimEvents(ctx context.Context, limit int, maxAttempts int, lease time.Duration) ([]entity.Event, error) {
+	var events []models.OutboxEvent
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		events, err = getMultiRecord(ctx, tx, &models.OutboxEvent{},
+			WithWhere("processed_at IS NULL"),
+			WithWhere("attempts < ?", maxAttempts),
+			WithWhere("locked_until IS NULL OR locked_until <= ?", time.Now()),
+			WithOrder("created_at asc"),
+			WithLimit(limit),
+			WithSkipLocked(),
+		)
+		if err != nil {
+			return fmt.Errorf("get outbox events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := []uuid.UUID{}
+		for _, event := range events {
+			ids = append(ids, event.Id)
+		}
+
+		return tx.WithContext(ctx).
+			Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("locked_until", time.Now().Add(lease)).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformSlice[models.OutboxEvent, entity.Event](events), nil
+}
+
+// MarkEventHandled record that handler got event, false means it was already handled
+func (r *OutboxRepo) MarkEventHandled(ctx context.Context, eventID uuid.UUID, handler string) (bool, error) {
+	queryRes := conn(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.EventHandled{EventID: eventID, Handler: handler})
+	if queryRes.Error != nil {
+		return false, queryRes.Error
+	}
+
+	return queryRes.RowsAffected == 1, nil
+}
+
+func (r *OutboxRepo) MarkEventProcessed(ctx context.Context, eventID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("id = ?", eventID).
+		Updates(map[string]any{
+			"processed_at": time.Now(),
+			"locked_until": nil,
+		}).
+		Error
+}
+
+// FailEvent count failed attempt, event is retried after retryAt
+func (r *OutboxRepo) FailEvent(ctx context.Context, eventID uuid.UUID, errText string, retryAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("id = ?", eventID).
+		Updates(map[string]any{
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   errText,
+			"locked_until": retryAt,
+		}).
+		Error
+}
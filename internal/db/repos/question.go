@@ -0,0 +1,77 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type QuestionRepo struct {
+	db *gorm.DB
+}
+
+func (r *QuestionRepo) GetClear() *gorm.DB { return r.db }
+
+func NewQuestionRepo(cfg *config.DB) (*QuestionRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &QuestionRepo{
+		db: db,
+	}, nil
+}
+
+func (r *QuestionRepo) CreateQuestion(ctx context.Context, question *entity.TenderQuestion) (*entity.TenderQuestion, error) {
+	questionDB := utils.MustTransformObj[entity.TenderQuestion, models.TenderQuestion](question)
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.TenderQuestion{}, questionDB); err != nil {
+		return nil, fmt.Errorf("create question: %w", err)
+	}
+
+	return utils.MustTransformObj[models.TenderQuestion, entity.TenderQuestion](questionDB), nil
+}
+
+func (r *QuestionRepo) GetQuestionByID(ctx context.Context, id uuid.UUID) (*entity.TenderQuestion, error) {
+	return getSingleMappedRecord[entity.TenderQuestion, models.TenderQuestion](ctx, conn(ctx, r.db), entity.ErrQuestionNotFound, WithWhere("id = ?", id))
+}
+
+func (r *QuestionRepo) GetQuestionsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.TenderQuestion], error) {
+	return getPageMappedRecord[entity.TenderQuestion, models.TenderQuestion](ctx, conn(ctx, r.db), sort, pag, filters...)
+}
+
+// AnswerQuestion set or replace answer, answered question becomes public
+func (r *QuestionRepo) AnswerQuestion(ctx context.Context, id uuid.UUID, answer string, answererID uuid.UUID) (*entity.TenderQuestion, error) {
+	queryRes := conn(ctx, r.db).WithContext(ctx).
+		Model(&models.TenderQuestion{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"answer":      answer,
+			"answerer_id": answererID,
+			"answered_at": time.Now(),
+		})
+	if queryRes.Error != nil {
+		return nil, queryRes.Error
+	}
+	if queryRes.RowsAffected == 0 {
+		return nil, entity.ErrQuestionNotFound
+	}
+
+	return r.GetQuestionByID(ctx, id)
+}
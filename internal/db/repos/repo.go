@@ -9,10 +9,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
@@ -58,20 +63,56 @@ func (c *ctrl) initIfNeed(db *gorm.DB) error {
 
 	db.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";")
 
+	db.Exec("CREATE TYPE organization_type AS ENUM ('IE', 'LLC', 'JSC');")
+	db.Exec("CREATE TYPE organization_role_type AS ENUM ('Owner', 'TenderManager', 'Evaluator', 'Viewer');")
+	db.Exec("CREATE TYPE invitation_status_type AS ENUM ('Pending', 'Accepted', 'Declined', 'Revoked');")
+
 	db.Exec("CREATE TYPE service_type AS ENUM ('Construction', 'Delivery', 'Manufacture');")
 	db.Exec("CREATE TYPE tender_status_type AS ENUM ('Created', 'Published', 'Closed');")
 
 	db.Exec("CREATE TYPE author_type AS ENUM ('Organization', 'User');")
 	db.Exec("CREATE TYPE bid_status_type AS ENUM ('Created', 'Published', 'Canceled');")
 
+	db.Exec("CREATE TYPE delivery_status_type AS ENUM ('Pending', 'Succeeded', 'Failed');")
+
+	// replaced by partial idx_review_actor_active
+	db.Exec("DROP INDEX IF EXISTS idx_review_actor;")
+
 	err := db.AutoMigrate(
+		&models.User{},
+		&models.Organization{},
+		&models.OrganizationResponsible{},
+		&models.OrganizationInvitation{},
+		&models.OrganizationAudit{},
+		&models.ApiKey{},
+
 		&models.Tender{},
 		&models.TenderVersion{},
+		&models.TenderOpening{},
+		&models.TenderOpeningBid{},
+		&models.TenderOpeningVote{},
 
 		&models.Bid{},
 		&models.BidVersion{},
 		&models.BidRewiew{},
+		&models.ReviewVersion{},
 		&models.BidShip{},
+		&models.OrganizationReview{},
+
+		&models.OutboxEvent{},
+		&models.EventHandled{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.Email{},
+
+		&models.Attachment{},
+
+		&models.TenderQuestion{},
+
+		&models.BidMessage{},
 	)
 
 	c.inited = true
@@ -122,8 +163,125 @@ var (
 			return db
 		})
 	}
+
+	WithPreload = func(query string) FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			return db.Preload(query)
+		})
+	}
+
+	WithJoins = func(query string, args ...any) FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			return db.Joins(query, args...)
+		})
+	}
+
+	WithLockForUpdate = func() FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			return db.Clauses(clause.Locking{Strength: "UPDATE"})
+		})
+	}
+
+	// WithSkipLocked lock rows for update skipping rows locked by others
+	WithSkipLocked = func() FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			return db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		})
+	}
+
+	WithLimit = func(limit int) FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			return db.Limit(limit)
+		})
+	}
+
+	WithOffset = func(offset int) FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			return db.Offset(offset)
+		})
+	}
+
+	WithFilters = func(conds []entity.FilterCond) FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			for _, cond := range conds {
+				db = db.Where(filterCondSQL(cond))
+			}
+			return db
+		})
+	}
+
+	WithSort = func(sort []entity.SortField) FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			for _, s := range sort {
+				db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Field}, Desc: s.Desc})
+			}
+			return db
+		})
+	}
+
+	// WithKeyset select rows after cursor in (sort..., id) order
+	WithKeyset = func(sort []entity.SortField, cursor entity.Cursor) FilterOption {
+		return FilterOption(func(db *gorm.DB) *gorm.DB {
+			return db.Where(keysetSQL(sort, cursor))
+		})
+	}
 )
 
+func keysetSQL(sort []entity.SortField, cursor entity.Cursor) clause.Expression {
+	sort = append(slices.Clone(sort), entity.SortField{Field: "id"})
+	values := append(slices.Clone(cursor.Values), cursor.ID)
+
+	ors := []clause.Expression{}
+	for i, s := range sort {
+		ands := []clause.Expression{}
+		for j := range i {
+			ands = append(ands, clause.Eq{Column: clause.Column{Name: sort[j].Field}, Value: values[j]})
+		}
+
+		column := clause.Column{Name: s.Field}
+		if s.Desc {
+			ands = append(ands, clause.Lt{Column: column, Value: values[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: column, Value: values[i]})
+		}
+		ors = append(ors, clause.And(ands...))
+	}
+
+	return clause.Or(ors...)
+}
+
+func filterCondSQL(cond entity.FilterCond) clause.Expression {
+	column := clause.Column{Name: cond.Field}
+
+	switch cond.Op {
+	case entity.OpEq:
+		return clause.Eq{Column: column, Value: cond.Values[0]}
+	case entity.OpNotEq:
+		return clause.Neq{Column: column, Value: cond.Values[0]}
+	case entity.OpGt:
+		return clause.Gt{Column: column, Value: cond.Values[0]}
+	case entity.OpGte:
+		return clause.Gte{Column: column, Value: cond.Values[0]}
+	case entity.OpLt:
+		return clause.Lt{Column: column, Value: cond.Values[0]}
+	case entity.OpLte:
+		return clause.Lte{Column: column, Value: cond.Values[0]}
+	case entity.OpIn:
+		return clause.IN{Column: column, Values: cond.Values}
+	case entity.OpNotIn:
+		return clause.Not(clause.IN{Column: column, Values: cond.Values})
+	}
+
+	return clause.Expr{SQL: "FALSE"}
+}
+
+// isUniqueViolation report if insert or update failed on unique constraint,
+// it settles races which check before insert can't
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
 func createRecord[T any](ctx context.Context, db *gorm.DB, model *T, value *T, opts ...FilterOption) error {
 	query := db.WithContext(ctx).Model(model)
 	for _, opt := range opts {
@@ -163,6 +321,21 @@ func getMultiRecord[T any](ctx context.Context, db *gorm.DB, model *T, opts ...F
 	return resp, nil
 }
 
+func countRecord[T any](ctx context.Context, db *gorm.DB, model *T, opts ...FilterOption) (int64, error) {
+	var count int64
+
+	query := db.WithContext(ctx).Model(model)
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func getSingleMappedRecord[E, M any](ctx context.Context, db *gorm.DB, errNotFound error, opts ...FilterOption) (*E, error) {
 	var model M
 	resp, err := getSingleRecord(ctx, db, &model, opts...)
@@ -170,6 +343,7 @@ func getSingleMappedRecord[E, M any](ctx context.Context, db *gorm.DB, errNotFou
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errNotFound
 		}
+		return nil, err
 	}
 
 	return utils.MustTransformObj[M, E](resp), nil
@@ -184,3 +358,115 @@ func getMultiMappedRecord[E, M any](ctx context.Context, db *gorm.DB, opts ...Fi
 
 	return utils.MustTransformSlice[M, E](resp), nil
 }
+
+// getPageMappedRecord get page ordered by sort and id, page is selected by cursor if set, else by offset
+func getPageMappedRecord[E, M any](ctx context.Context, db *gorm.DB, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[E], error) {
+	var model M
+	page := entity.Page[E]{}
+
+	// counting is costly, so only on demand
+	if pag.WithTotal {
+		total, err := countRecord(ctx, db, &model, filters...)
+		if err != nil {
+			return nil, fmt.Errorf("count: %w", err)
+		}
+		page.Total = &total
+	}
+
+	order := append(slices.Clone(sort), entity.SortField{Field: "id"})
+	opts := append(slices.Clone(filters), WithSort(order))
+
+	if pag.Cursor != nil {
+		if !slices.Equal(pag.Cursor.Sort, sort) || len(pag.Cursor.Values) != len(sort) {
+			return nil, entity.ErrInvalidCursor
+		}
+		opts = append(opts, WithKeyset(sort, *pag.Cursor))
+	} else {
+		opts = append(opts, WithOffset(pag.Offset))
+	}
+	// one extra row to know if next page exists
+	opts = append(opts, WithLimit(pag.Limit+1))
+
+	resp, err := getMultiRecord(ctx, db, &model, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp) > pag.Limit {
+		resp = resp[:pag.Limit]
+		if pag.Limit > 0 {
+			cursor, err := cursorFromRecord(ctx, db, &resp[len(resp)-1], sort)
+			if err != nil {
+				return nil, fmt.Errorf("make cursor: %w", err)
+			}
+			page.NextCursor = cursor
+		}
+	}
+	page.Items = utils.MustTransformSlice[M, E](resp)
+
+	return &page, nil
+}
+
+// streamMappedRecord pass records ordered by sort and id to fn one by one as they are read from db cursor,
+// records are not buffered, so fn should not hold them
+func streamMappedRecord[E, M any](ctx context.Context, db *gorm.DB, sort []entity.SortField, fn func(*E) error, filters ...FilterOption) error {
+	var model M
+
+	order := append(slices.Clone(sort), entity.SortField{Field: "id"})
+	query := db.WithContext(ctx).Model(&model)
+	for _, opt := range append(slices.Clone(filters), WithSort(order)) {
+		query = opt(query)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record M
+		if err := query.ScanRows(rows, &record); err != nil {
+			return fmt.Errorf("scan row: %w", err)
+		}
+		if err := fn(utils.MustTransformObj[M, E](&record)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func cursorFromRecord[M any](ctx context.Context, db *gorm.DB, record *M, sort []entity.SortField) (*entity.Cursor, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(record).Elem()
+	valueOf := func(column string) (any, error) {
+		field := stmt.Schema.LookUpField(column)
+		if field == nil {
+			return nil, fmt.Errorf("unknown column %s", column)
+		}
+		value, _ := field.ValueOf(ctx, rv)
+		return value, nil
+	}
+
+	cursor := entity.Cursor{Sort: sort}
+	for _, s := range sort {
+		value, err := valueOf(s.Field)
+		if err != nil {
+			return nil, err
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	id, err := valueOf("id")
+	if err != nil {
+		return nil, err
+	}
+	cursor.ID, _ = id.(uuid.UUID)
+
+	return &cursor, nil
+}
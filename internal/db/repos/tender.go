@@ -42,7 +42,7 @@ func NewTenderRepo(cfg *config.DB) (*TenderRepo, error) {
 func (r *TenderRepo) createBackup(ctx context.Context, tender *models.Tender) error {
 	backup := trnsfrm.TenderToTenderVersion(tender)
 
-	err := createRecord(ctx, r.db, &models.TenderVersion{}, backup)
+	err := createRecord(ctx, conn(ctx, r.db), &models.TenderVersion{}, backup)
 
 	return err
 }
@@ -50,7 +50,7 @@ func (r *TenderRepo) createBackup(ctx context.Context, tender *models.Tender) er
 func (r *TenderRepo) CreateTender(ctx context.Context, tender *entity.Tender) (*entity.Tender, error) {
 	tenderDB := utils.MustTransformObj[entity.Tender, models.Tender](tender)
 
-	if err := createRecord(ctx, r.db, &models.Tender{}, tenderDB); err != nil {
+	if err := createRecord(ctx, conn(ctx, r.db), &models.Tender{}, tenderDB); err != nil {
 		return nil, fmt.Errorf("create tender: %w", err)
 	}
 
@@ -61,48 +61,24 @@ func (r *TenderRepo) CreateTender(ctx context.Context, tender *entity.Tender) (*
 	return utils.MustTransformObj[models.Tender, entity.Tender](tenderDB), nil
 }
 
-func (r *TenderRepo) GetUserByUserName(ctx context.Context, username string) (*entity.User, error) {
-	return getSingleMappedRecord[entity.User, models.User](ctx, r.db, entity.ErrUserNotFound, WithWhere("username = ?", username))
-}
-
-func (r *TenderRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	return getSingleMappedRecord[entity.User, models.User](ctx, r.db, entity.ErrUserNotFound, WithWhere("id = ?", id))
-}
-
-func (r *TenderRepo) GetOrgByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
-	return getSingleMappedRecord[entity.Organization, models.Organization](ctx, r.db, entity.ErrOrgNotFound, WithWhere("id = ?", id))
-}
-
-func (r *TenderRepo) GetOrgUsersIDsByID(ctx context.Context, id uuid.UUID) (uuid.UUIDs, error) {
-	orgsUsers, err := getMultiRecord(ctx, r.db, &models.OrganizationResponsible{},
-		WithWhere("organization_id = ?", id),
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return trnsfrm.OrgRespToUserUUIDSlice(orgsUsers), nil
-}
-
 func (r *TenderRepo) GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error) {
-	return getSingleMappedRecord[entity.Tender, models.Tender](ctx, r.db, entity.ErrTenderNotFound, WithWhere("id = ?", tenderID))
+	return getSingleMappedRecord[entity.Tender, models.Tender](ctx, conn(ctx, r.db), entity.ErrTenderNotFound, WithWhere("id = ?", tenderID))
 }
 
 func (r *TenderRepo) GetTendersByFilter(ctx context.Context, filters ...FilterOption) ([]entity.Tender, error) {
-	return getMultiMappedRecord[entity.Tender, models.Tender](ctx, r.db, filters...)
+	return getMultiMappedRecord[entity.Tender, models.Tender](ctx, conn(ctx, r.db), filters...)
 }
 
-func (r *TenderRepo) GetUserOrgsUUIDs(ctx context.Context, userID uuid.UUID) (uuid.UUIDs, error) {
-	resp, err := getMultiRecord(ctx, r.db, &models.OrganizationResponsible{}, WithWhere("user_id = ?", userID))
-	if err != nil {
-		return nil, err
-	}
+func (r *TenderRepo) GetTendersPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.Tender], error) {
+	return getPageMappedRecord[entity.Tender, models.Tender](ctx, conn(ctx, r.db), sort, pag, filters...)
+}
 
-	return trnsfrm.OrgRespToOrgUUIDSlice(resp), nil
+func (r *TenderRepo) StreamTenders(ctx context.Context, sort []entity.SortField, fn func(*entity.Tender) error, filters ...FilterOption) error {
+	return streamMappedRecord[entity.Tender, models.Tender](ctx, conn(ctx, r.db), sort, fn, filters...)
 }
 
 func (r *TenderRepo) UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, newStatus entity.TenderStatusType) error {
-	queryRes := r.db.WithContext(ctx).
+	queryRes := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Tender{}).
 		Where("id = ?", tenderID).
 		Update("status", newStatus)
@@ -121,7 +97,7 @@ func (r *TenderRepo) UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID,
 func (r *TenderRepo) PatchTender(ctx context.Context, tenderID uuid.UUID, patchTender *entity.Tender) (*entity.Tender, error) {
 	patchTenderDB := utils.MustTransformObj[entity.Tender, models.Tender](patchTender)
 
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Tender{}).
 		Where("id = ?", tenderID).
 		Updates(patchTenderDB).
@@ -129,7 +105,7 @@ func (r *TenderRepo) PatchTender(ctx context.Context, tenderID uuid.UUID, patchT
 		return nil, err
 	}
 
-	tenderDB, err := getSingleRecord(ctx, r.db, &models.Tender{}, WithWhere("id = ?", tenderID))
+	tenderDB, err := getSingleRecord(ctx, conn(ctx, r.db), &models.Tender{}, WithWhere("id = ?", tenderID))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrTenderNotFound
@@ -139,7 +115,7 @@ func (r *TenderRepo) PatchTender(ctx context.Context, tenderID uuid.UUID, patchT
 
 	tenderDB.Version += 1
 
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Tender{}).
 		Where("id = ?", tenderID).
 		Update("version", tenderDB.Version).
@@ -154,8 +130,8 @@ func (r *TenderRepo) PatchTender(ctx context.Context, tenderID uuid.UUID, patchT
 	return utils.MustTransformObj[models.Tender, entity.Tender](tenderDB), nil
 }
 
-func (r *TenderRepo) RollbackTender(ctx context.Context, tenderID uuid.UUID, version int) (*entity.Tender, error) {
-	currTender, err := getSingleRecord(ctx, r.db, &models.Tender{}, WithWhere("id = ?", tenderID))
+func (r *TenderRepo) RollbackTender(ctx context.Context, tenderID uuid.UUID, version int, actor entity.ActorRef) (*entity.Tender, error) {
+	currTender, err := getSingleRecord(ctx, conn(ctx, r.db), &models.Tender{}, WithWhere("id = ?", tenderID))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrTenderNotFound
@@ -166,7 +142,7 @@ func (r *TenderRepo) RollbackTender(ctx context.Context, tenderID uuid.UUID, ver
 	newStatus := currTender.Status
 	newVersion := currTender.Version + 1
 
-	backupTenderDB, err := getSingleRecord(ctx, r.db, &models.TenderVersion{},
+	backupTenderDB, err := getSingleRecord(ctx, conn(ctx, r.db), &models.TenderVersion{},
 		WithWhere("tender_id = ?", tenderID),
 		WithWhere("version = ?", version),
 	)
@@ -180,8 +156,10 @@ func (r *TenderRepo) RollbackTender(ctx context.Context, tenderID uuid.UUID, ver
 	rollbackTender := trnsfrm.TenderVersionToTender(backupTenderDB)
 	rollbackTender.Status = newStatus
 	rollbackTender.Version = newVersion
+	rollbackTender.ActorType = string(actor.Type)
+	rollbackTender.ActorID = &actor.ID
 
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).WithContext(ctx).
 		Model(&models.Tender{}).
 		Where("id = ?", tenderID).
 		Updates(rollbackTender).
@@ -195,3 +173,65 @@ func (r *TenderRepo) RollbackTender(ctx context.Context, tenderID uuid.UUID, ver
 
 	return utils.MustTransformObj[models.Tender, entity.Tender](rollbackTender), nil
 }
+
+// AddOpeningVote save vote of responsible to open sealed tender, false means responsible already voted
+func (r *TenderRepo) AddOpeningVote(ctx context.Context, tenderID uuid.UUID, userID uuid.UUID) (bool, error) {
+	_, err := getSingleRecord(ctx, conn(ctx, r.db), &models.TenderOpeningVote{},
+		WithWhere("tender_id = ?", tenderID),
+		WithWhere("user_id = ?", userID),
+	)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.TenderOpeningVote{}, &models.TenderOpeningVote{TenderID: tenderID, UserID: userID}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *TenderRepo) CountOpeningVotes(ctx context.Context, tenderID uuid.UUID) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).WithContext(ctx).
+		Model(&models.TenderOpeningVote{}).
+		Where("tender_id = ?", tenderID).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+// OpenTender mark sealed tender opened and save protocol with revealed bids
+func (r *TenderRepo) OpenTender(ctx context.Context, protocol *entity.OpeningProtocol) (*entity.OpeningProtocol, error) {
+	queryRes := conn(ctx, r.db).WithContext(ctx).
+		Model(&models.Tender{}).
+		Where("id = ?", protocol.TenderID).
+		Where("opened_at IS NULL").
+		Update("opened_at", protocol.OpenedAt)
+	if queryRes.Error != nil {
+		return nil, queryRes.Error
+	}
+	if queryRes.RowsAffected == 0 {
+		return nil, entity.ErrTenderOpened
+	}
+
+	protocolDB := utils.MustTransformObj[entity.OpeningProtocol, models.TenderOpening](protocol)
+
+	if err := createRecord(ctx, conn(ctx, r.db), &models.TenderOpening{}, protocolDB); err != nil {
+		return nil, fmt.Errorf("create opening protocol: %w", err)
+	}
+
+	return utils.MustTransformObj[models.TenderOpening, entity.OpeningProtocol](protocolDB), nil
+}
+
+func (r *TenderRepo) GetOpeningProtocol(ctx context.Context, tenderID uuid.UUID) (*entity.OpeningProtocol, error) {
+	return getSingleMappedRecord[entity.OpeningProtocol, models.TenderOpening](ctx, conn(ctx, r.db), entity.ErrOpeningNotFound,
+		WithWhere("tender_id = ?", tenderID),
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Bids", func(db *gorm.DB) *gorm.DB { return db.Order("name, bid_id") })
+		},
+	)
+}
@@ -0,0 +1,54 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"context"
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// conn return transaction from context if repo call is a part of it
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db
+}
+
+// TxManager run several repo calls in one transaction, repos pick it up from context
+type TxManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(cfg *config.DB) (*TxManager, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &TxManager{
+		db: db,
+	}, nil
+}
+
+// WithinTx run fn in transaction, nested calls join outer transaction
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
@@ -0,0 +1,94 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type UserRepo struct {
+	db *gorm.DB
+}
+
+func (r *UserRepo) GetClear() *gorm.DB { return r.db }
+
+func NewUserRepo(cfg *config.DB) (*UserRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &UserRepo{
+		db: db,
+	}, nil
+}
+
+func (r *UserRepo) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	userDB := utils.MustTransformObj[entity.User, models.User](user)
+
+	if err := createRecord(ctx, r.db, &models.User{}, userDB); err != nil {
+		if isUniqueViolation(err) {
+			return nil, entity.ErrUsernameTaken
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	return utils.MustTransformObj[models.User, entity.User](userDB), nil
+}
+
+func (r *UserRepo) GetUserByUserName(ctx context.Context, username string) (*entity.User, error) {
+	return getSingleMappedRecord[entity.User, models.User](ctx, r.db, entity.ErrUserNotFound, WithWhere("username = ?", username))
+}
+
+func (r *UserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	return getSingleMappedRecord[entity.User, models.User](ctx, r.db, entity.ErrUserNotFound, WithWhere("id = ?", id))
+}
+
+func (r *UserRepo) GetUsersByFilter(ctx context.Context, filters ...FilterOption) ([]entity.User, error) {
+	return getMultiMappedRecord[entity.User, models.User](ctx, conn(ctx, r.db), filters...)
+}
+
+func (r *UserRepo) PatchUser(ctx context.Context, userID uuid.UUID, patchUser *entity.User) (*entity.User, error) {
+	userDB := utils.MustTransformObj[entity.User, models.User](patchUser)
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(userDB).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetUserByID(ctx, userID)
+}
+
+func (r *UserRepo) DeactivateUser(ctx context.Context, userID uuid.UUID) error {
+	queryRes := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("active", false)
+
+	if queryRes.Error != nil {
+		return queryRes.Error
+	}
+
+	if queryRes.RowsAffected == 0 {
+		return entity.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package repos
+
+import (
+	"avito/internal/config"
+	"avito/internal/db/models"
+	"avito/internal/entity"
+	"avito/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type WebhookRepo struct {
+	db *gorm.DB
+}
+
+func (r *WebhookRepo) GetClear() *gorm.DB { return r.db }
+
+func NewWebhookRepo(cfg *config.DB) (*WebhookRepo, error) {
+	log := newLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: cfg.PostgresConn,
+	}), &gorm.Config{
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create db gorm obj: %w", err)
+	}
+
+	repoCtrl.initIfNeed(db)
+
+	return &WebhookRepo{
+		db: db,
+	}, nil
+}
+
+func (r *WebhookRepo) CreateWebhook(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	webhookDB := utils.MustTransformObj[entity.Webhook, models.Webhook](webhook)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createRecord(ctx, tx, &models.Webhook{}, webhookDB); err != nil {
+			return fmt.Errorf("create webhook: %w", err)
+		}
+
+		return createAudit(ctx, tx, webhookDB.OrganizationID, webhookDB.CreatorID, entity.AuditWebhookCreated, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MustTransformObj[models.Webhook, entity.Webhook](webhookDB), nil
+}
+
+func (r *WebhookRepo) GetWebhookByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error) {
+	return getSingleMappedRecord[entity.Webhook, models.Webhook](ctx, r.db, entity.ErrWebhookNotFound, WithWhere("id = ?", id))
+}
+
+func (r *WebhookRepo) GetWebhooksPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.Webhook], error) {
+	return getPageMappedRecord[entity.Webhook, models.Webhook](ctx, r.db, sort, pag, filters...)
+}
+
+// DeleteWebhook delete webhook with its delivery log
+func (r *WebhookRepo) DeleteWebhook(ctx context.Context, id uuid.UUID, actorID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		webhookDB, err := getSingleRecord(ctx, tx, &models.Webhook{}, WithWhere("id = ?", id), WithLockForUpdate())
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entity.ErrWebhookNotFound
+			}
+			return err
+		}
+
+		if err := tx.WithContext(ctx).Delete(&models.Webhook{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return createAudit(ctx, tx, webhookDB.OrganizationID, actorID, entity.AuditWebhookDeleted, nil)
+	})
+}
+
+func (r *WebhookRepo) GetDeliveryByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	return getSingleMappedRecord[entity.WebhookDelivery, models.WebhookDelivery](ctx, r.db, entity.ErrDeliveryNotFound, WithWhere("id = ?", id))
+}
+
+func (r *WebhookRepo) GetDeliveriesPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...FilterOption) (*entity.Page[entity.WebhookDelivery], error) {
+	return getPageMappedRecord[entity.WebhookDelivery, models.WebhookDelivery](ctx, r.db, sort, pag, filters...)
+}
+
+// Redeliver create new pending delivery of the same event, old one stays in log
+func (r *WebhookRepo) Redeliver(ctx context.Context, deliveryID uuid.UUID) (*entity.WebhookDelivery, error) {
+	origin, err := getSingleRecord(ctx, r.db, &models.WebhookDelivery{}, WithWhere("id = ?", deliveryID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveryDB := &models.WebhookDelivery{
+		WebhookID:     origin.WebhookID,
+		EventID:       origin.EventID,
+		EventType:     origin.EventType,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: &now,
+	}
+	if err := createRecord(ctx, r.db, &models.WebhookDelivery{}, deliveryDB); err != nil {
+		return nil, fmt.Errorf("create delivery: %w", err)
+	}
+
+	return utils.MustTransformObj[models.WebhookDelivery, entity.WebhookDelivery](deliveryDB), nil
+}
+
+// CreateDeliveries create pending deliveries of event for subscribed webhooks of event organizations
+func (r *WebhookRepo) CreateDeliveries(ctx context.Context, event *entity.Event) error {
+	if len(event.OrganizationIDs) == 0 {
+		return nil
+	}
+
+	webhooks, err := getMultiRecord(ctx, conn(ctx, r.db), &models.Webhook{},
+		WithWhere("organization_id IN ?", event.OrganizationIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("get webhooks: %w", err)
+	}
+
+	now := time.Now()
+	for _, webhook := range webhooks {
+		if !slices.Contains(webhook.Events, string(event.Type)) {
+			continue
+		}
+
+		if err := createRecord(ctx, conn(ctx, r.db), &models.WebhookDelivery{}, &models.WebhookDelivery{
+			WebhookID:     webhook.Id,
+			EventID:       event.Id,
+			EventType:     string(event.Type),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: &now,
+		}); err != nil {
+			return fmt.Errorf("create delivery: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimDeliveries take due pending deliveries and hide them from other workers for lease
+func (r *WebhookRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.DeliveryTask, error) {
+	var deliveries []models.WebhookDelivery
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		deliveries, err = getMultiRecord(ctx, tx, &models.WebhookDelivery{},
+			WithWhere("status = ?", models.DeliveryPending),
+			WithWhere("next_attempt_at <= ?", time.Now()),
+			WithOrder("next_attempt_at asc"),
+			WithLimit(limit),
+			WithSkipLocked(),
+		)
+		if err != nil {
+			return fmt.Errorf("get deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := []uuid.UUID{}
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.Id)
+		}
+
+		return tx.WithContext(ctx).
+			Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", time.Now().Add(lease)).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []entity.DeliveryTask{}
+	for _, delivery := range deliveries {
+		webhook, err := getSingleRecord(ctx, r.db, &models.Webhook{}, WithWhere("id = ?", delivery.WebhookID))
+		if err != nil {
+			// webhook deleted after claim
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("get webhook: %w", err)
+		}
+
+		event, err := getSingleRecord(ctx, r.db, &models.OutboxEvent{}, WithWhere("id = ?", delivery.EventID))
+		if err != nil {
+			return nil, fmt.Errorf("get event: %w", err)
+		}
+
+		tasks = append(tasks, entity.DeliveryTask{
+			Delivery: *utils.MustTransformObj[models.WebhookDelivery, entity.WebhookDelivery](&delivery),
+			Webhook:  *utils.MustTransformObj[models.Webhook, entity.Webhook](webhook),
+			Event:    *utils.MustTransformObj[models.OutboxEvent, entity.Event](event),
+		})
+	}
+
+	return tasks, nil
+}
+
+// SaveDeliveryAttempt store result of attempt
+func (r *WebhookRepo) SaveDeliveryAttempt(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	return r.db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("id = ?", delivery.Id).
+		Updates(map[string]any{
+			"status":          models.DeliveryStatusType(delivery.Status),
+			"attempts":        delivery.Attempts,
+			"response_status": delivery.ResponseStatus,
+			"last_error":      delivery.LastError,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"updated_at":      time.Now(),
+		}).
+		Error
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ActorType string
+
+const (
+	ActorUser   ActorType = "User"
+	ActorApiKey ActorType = "ApiKey"
+)
+
+// ActorRef is who made change, user or api key
+type ActorRef struct {
+	Type ActorType
+	ID   uuid.UUID
+}
+
+// ApiKey grants machine access to one organization, only listed permissions (authz actions) are allowed
+type ApiKey struct {
+	Id             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organizationId"`
+	Name           string     `json:"name"`
+	Prefix         string     `json:"prefix"`
+	Permissions    []string   `json:"permissions"`
+	CreatorID      uuid.UUID  `json:"creatorId"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	LastUsedAt     *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+
+	Hash string `json:"-"`
+}
+
+func (k ApiKey) MarshalJSON() ([]byte, error) {
+	type Alias ApiKey
+	formatOpt := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		s := t.Format(time.RFC3339)
+		return &s
+	}
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt  string  `json:"createdAt"`
+			LastUsedAt *string `json:"lastUsedAt,omitempty"`
+			RevokedAt  *string `json:"revokedAt,omitempty"`
+		}{
+			Alias:      (*Alias)(&k),
+			CreatedAt:  k.CreatedAt.Format(time.RFC3339),
+			LastUsedAt: formatOpt(k.LastUsedAt),
+			RevokedAt:  formatOpt(k.RevokedAt),
+		},
+	)
+}
+
+// IssuedApiKey is returned only once on creation, secret is not stored
+type IssuedApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+func (k IssuedApiKey) MarshalJSON() ([]byte, error) {
+	apiKey, err := json.Marshal(k.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(apiKey, &fields); err != nil {
+		return nil, err
+	}
+	fields["key"] = k.Key
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentParentType string
+
+const (
+	AttachmentTender AttachmentParentType = "tender"
+	AttachmentBid    AttachmentParentType = "bid"
+)
+
+// Attachment is immutable uploaded file, parent version lists ids of its attachments
+type Attachment struct {
+	Id          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	ParentType AttachmentParentType `json:"-"`
+	ParentID   uuid.UUID            `json:"-"`
+	StorageKey string               `json:"-"`
+
+	// who uploaded
+	ActorType ActorType `json:"-"`
+	ActorID   uuid.UUID `json:"-"`
+}
+
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	type Alias Attachment
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+		}{
+			Alias:     (*Alias)(&a),
+			CreatedAt: a.CreatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+// Upload is file as it is received from client
+type Upload struct {
+	Name    string
+	Content io.Reader
+}
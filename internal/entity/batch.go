@@ -0,0 +1,10 @@
+package entity
+
+import "github.com/google/uuid"
+
+// BatchItem is result of one item of batch operation, Err is nil for applied item
+type BatchItem[T any] struct {
+	ID   uuid.UUID
+	Item *T
+	Err  error
+}
@@ -47,38 +47,177 @@ type Bid struct {
 	CreatedAt   time.Time     `json:"createdAt"`
 	ShipsCount  int           `json:"-"`
 	Kvorum      int           `json:"-"`
+	// final decision: rejected by any voter or approved by quorum, empty while undecided
+	Decision BidDecisionType `json:"decision,omitempty"`
+	// reason of last withdrawal, cleared on resubmit
+	WithdrawReason string `json:"withdrawReason,omitempty"`
+	Resubmissions  int    `json:"resubmissions,omitempty"`
+	// contents are hidden until sealed tender is opened
+	Sealed bool `json:"sealed,omitempty"`
+	// pseudonym shown instead of author while evaluation of tender is anonymous
+	AuthorLabel string `json:"authorLabel,omitempty"`
+
+	// attachments of this version, listed by separate endpoint
+	AttachmentIDs []uuid.UUID `json:"-"`
+
+	// who made this version
+	ActorType ActorType  `json:"-"`
+	ActorID   *uuid.UUID `json:"-"`
 }
 
 func (b Bid) MarshalJSON() ([]byte, error) {
 	type Alias Bid
+	var (
+		authorType *BidAuthorType
+		authorID   *uuid.UUID
+	)
+	if b.AuthorLabel == "" {
+		authorType, authorID = &b.AuthorType, &b.AuthorID
+	}
 	return json.Marshal(
 		struct {
 			*Alias
-			CreatedAt string `json:"createdAt"`
+			CreatedAt  string         `json:"createdAt"`
+			AuthorType *BidAuthorType `json:"authorType,omitempty"`
+			AuthorID   *uuid.UUID     `json:"authorId,omitempty"`
 		}{
-			Alias:     (*Alias)(&b),
-			CreatedAt: b.CreatedAt.Format(time.RFC3339),
+			Alias:      (*Alias)(&b),
+			CreatedAt:  b.CreatedAt.Format(time.RFC3339),
+			AuthorType: authorType,
+			AuthorID:   authorID,
 		},
 	)
 }
 
-type BidRewiew struct {
-	Id          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"createdAt"`
+// Seal hide bid contents from viewer who cant see them before tender opening
+func (b *Bid) Seal() {
+	b.Name = ""
+	b.Description = ""
+	b.WithdrawReason = ""
+	b.Sealed = true
+}
+
+// Anonymize replace author with pseudonym
+func (b *Bid) Anonymize(label string) {
+	b.AuthorType = ""
+	b.AuthorID = uuid.Nil
+	b.AuthorLabel = label
+}
+
+// ReviewRating is review scores by dimension, nil dimension is not rated
+type ReviewRating struct {
+	Quality       *int
+	Timeliness    *int
+	Communication *int
+}
 
-	BidID uuid.UUID `json:"-"`
+// Reputation is summary of reviews to bids of author,
+// trend is difference of recent average and average before it
+type Reputation struct {
+	Count         int64    `json:"count"`
+	Average       *float64 `json:"average"`
+	Quality       *float64 `json:"quality"`
+	Timeliness    *float64 `json:"timeliness"`
+	Communication *float64 `json:"communication"`
+	RecentAverage *float64 `json:"recentAverage"`
+	Trend         *float64 `json:"trend"`
+}
+
+type ReviewModerationStatus string
+
+const (
+	ReviewVisible ReviewModerationStatus = "Visible"
+	ReviewFlagged ReviewModerationStatus = "Flagged"
+	ReviewRemoved ReviewModerationStatus = "Removed"
+)
+
+var ReviewModerationStatusList = []ReviewModerationStatus{ReviewVisible, ReviewFlagged, ReviewRemoved}
+
+type ReviewDecision string
+
+const (
+	ReviewRestore ReviewDecision = "Restore"
+	ReviewRemove  ReviewDecision = "Remove"
+)
+
+var ReviewDecisionList = []ReviewDecision{ReviewRestore, ReviewRemove}
+
+type BidRewiew struct {
+	Id          uuid.UUID  `json:"id"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+
+	// scores from 1 to 5, nil is not rated
+	Quality       *int `json:"quality,omitempty"`
+	Timeliness    *int `json:"timeliness,omitempty"`
+	Communication *int `json:"communication,omitempty"`
+
+	// single public reply of bid author
+	Reply     *string    `json:"reply,omitempty"`
+	RepliedAt *time.Time `json:"repliedAt,omitempty"`
+
+	// flagged review is hidden until moderator restores or removes it,
+	// restored review cant be flagged again
+	ModerationStatus ReviewModerationStatus `json:"moderationStatus"`
+	FlagReason       *string                `json:"flagReason,omitempty"`
+	FlaggedAt        *time.Time             `json:"flaggedAt,omitempty"`
+	ModeratedAt      *time.Time             `json:"-"`
+
+	BidID     uuid.UUID  `json:"-"`
+	DeletedAt *time.Time `json:"-"`
+
+	// reviewer, one review per reviewer and bid
+	ActorType ActorType  `json:"-"`
+	ActorID   *uuid.UUID `json:"-"`
 }
 
 func (b BidRewiew) MarshalJSON() ([]byte, error) {
 	type Alias BidRewiew
+	formatOpt := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		s := t.Format(time.RFC3339)
+		return &s
+	}
 	return json.Marshal(
 		struct {
 			*Alias
-			CreatedAt string `json:"createdAt"`
+			CreatedAt string  `json:"createdAt"`
+			UpdatedAt *string `json:"updatedAt,omitempty"`
+			RepliedAt *string `json:"repliedAt,omitempty"`
+			FlaggedAt *string `json:"flaggedAt,omitempty"`
 		}{
 			Alias:     (*Alias)(&b),
 			CreatedAt: b.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: formatOpt(b.UpdatedAt),
+			RepliedAt: formatOpt(b.RepliedAt),
+			FlaggedAt: formatOpt(b.FlaggedAt),
+		},
+	)
+}
+
+// ReviewVersion is review text and scores before edit
+type ReviewVersion struct {
+	Description   string    `json:"description"`
+	Quality       *int      `json:"quality,omitempty"`
+	Timeliness    *int      `json:"timeliness,omitempty"`
+	Communication *int      `json:"communication,omitempty"`
+	EditedAt      time.Time `json:"editedAt"`
+
+	ReviewID uuid.UUID `json:"-"`
+}
+
+func (v ReviewVersion) MarshalJSON() ([]byte, error) {
+	type Alias ReviewVersion
+	return json.Marshal(
+		struct {
+			*Alias
+			EditedAt string `json:"editedAt"`
+		}{
+			Alias:    (*Alias)(&v),
+			EditedAt: v.EditedAt.Format(time.RFC3339),
 		},
 	)
 }
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email is rendered message queued for sending with its attempts
+type Email struct {
+	Id               uuid.UUID
+	UserID           uuid.UUID
+	EventID          uuid.UUID
+	NotificationType NotificationType
+	To               string
+	Subject          string
+	Body             string
+	Status           DeliveryStatusType
+	Attempts         int
+	LastError        string
+	NextAttemptAt    *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,24 +18,78 @@ const (
 var OrganizationTypeList = []OrganizationType{IE, LLC, JSC}
 
 type Organization struct {
-	Id          uuid.UUID
-	Name        string
-	Description string
-	Type        OrganizationType
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Id          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Type        OrganizationType `json:"type"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+func (o Organization) MarshalJSON() ([]byte, error) {
+	type Alias Organization
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+			UpdatedAt string `json:"updatedAt"`
+		}{
+			Alias:     (*Alias)(&o),
+			CreatedAt: o.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: o.UpdatedAt.Format(time.RFC3339),
+		},
+	)
 }
 
 type Pagination struct {
 	Limit  int
 	Offset int
+	// if set, used instead of offset
+	Cursor *Cursor
+	// count total rows matching filters
+	WithTotal bool
+}
+
+// Cursor points to the last row of previous page: values of sort fields and row id
+type Cursor struct {
+	Sort   []SortField
+	Values []any
+	ID     uuid.UUID
+}
+
+type Page[T any] struct {
+	Items      []T
+	NextCursor *Cursor
+	Total      *int64
 }
 
 type User struct {
-	Id        uuid.UUID
-	Username  string
-	FirstName string
-	LastName  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// contacts are private, shown only in own profile
+	Email  string `json:"-"`
+	Locale string `json:"-"`
+
+	PasswordHash string `json:"-"`
+	Active       bool   `json:"-"`
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	type Alias User
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+			UpdatedAt string `json:"updatedAt"`
+		}{
+			Alias:     (*Alias)(&u),
+			CreatedAt: u.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: u.UpdatedAt.Format(time.RFC3339),
+		},
+	)
 }
@@ -7,10 +7,29 @@ var (
 	ErrOrgNotFound    = errors.New("organization not found")
 	ErrTenderNotFound = errors.New("tender not found")
 	ErrBidNotFound    = errors.New("bid not found")
+
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrApiKeyNotFound       = errors.New("api key not found")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrQuestionNotFound     = errors.New("question not found")
+	ErrReviewNotFound       = errors.New("review not found")
+	ErrOpeningNotFound      = errors.New("tender is not opened")
 )
 
 var (
 	ErrUserNotSpecified = errors.New("only authorizated users have permissions to view this resource")
+	ErrUserDeactivated  = errors.New("user is deactivated")
+	ErrInvalidPassword  = errors.New("invalid password")
+	ErrUsernameTaken    = errors.New("username is already taken")
+	ErrInvalidApiKey    = errors.New("api key is invalid or revoked")
+)
+
+var (
+	ErrInvalidCursor = errors.New("cursor is invalid or does not match sort")
+	ErrDeadlinePast  = errors.New("deadline must be in the future")
 )
 
 var (
@@ -22,10 +41,52 @@ var (
 	ErrUserPermissionCreateTender = errors.New("user dont have permission to create tender for this org")
 	ErrUserPermissionTender       = errors.New("user dont have permission to this tender")
 	ErrUserPermissionBidsTender   = errors.New("user dont have permission to see bids for this tender")
+	ErrBidsSealed                 = errors.New("bids of sealed tender cant be filtered or sorted by contents before opening")
+	ErrBiddersHidden              = errors.New("bidders of anonymous tender are revealed after it is closed")
 	ErrCreateBidTender            = errors.New("cant create bid to not public tender")
+	ErrTenderDeadlinePassed       = errors.New("tender submission deadline has passed")
+	ErrQuestionTender             = errors.New("cant ask question to not public tender")
+	ErrQuestionsClosed            = errors.New("questions to this tender are closed")
 	ErrShipBidTender              = errors.New("cant ship not public bid")
+	ErrResubmitBidTender          = errors.New("cant resubmit bid to not public tender")
+	ErrResubmitLimit              = errors.New("bid resubmission limit is reached")
 	ErrFeedbackPermission         = errors.New("cant see this feedbacks")
 	ErrUserPermissionBid          = errors.New("user dont have permission to this bid")
 	ErrUserPermissionShipBid      = errors.New("user dont have permission to ship this bid")
 	ErrUserPermissionRewiew       = errors.New("cant create rewiew to not approved bid")
+	ErrReviewExists               = errors.New("bid is already reviewed by this responsible")
+	ErrOrgReviewExists            = errors.New("organization is already reviewed for this bid")
+	ErrBidNotDecided              = errors.New("organization can be reviewed only after decision on bid")
+	ErrUserPermissionReview       = errors.New("user dont have permission to this review")
+	ErrReviewEditWindow           = errors.New("review can be edited only soon after creation")
+	ErrReviewReplied              = errors.New("review already has reply")
+	ErrReviewFlagged              = errors.New("review is already flagged or moderated")
+	ErrReviewNotFlagged           = errors.New("review is not waiting for moderation")
+	ErrUserPermissionOrg          = errors.New("user dont have permission to this organization")
+	ErrUserPermissionInvitation   = errors.New("user dont have permission to this invitation")
+)
+
+var (
+	ErrInvitationNotPending = errors.New("invitation is already resolved")
+	ErrInvitationExists     = errors.New("user already has pending invitation to this organization")
+	ErrAlreadyResponsible   = errors.New("user is already responsible for this organization")
+	ErrNotResponsible       = errors.New("user is not responsible for this organization")
+	ErrLastResponsible      = errors.New("last responsible cant leave organization")
+	ErrLastOwner            = errors.New("organization must have at least one owner")
+	ErrApiKeyRevoked        = errors.New("api key is already revoked")
+	ErrBidCanceled          = errors.New("canceled bid can only be resubmitted")
+	ErrBidPublished         = errors.New("published bid can only be withdrawn")
+	ErrBidNotWithdrawable   = errors.New("only published bid without decision can be withdrawn")
+	ErrBidNotWithdrawn      = errors.New("only withdrawn bid can be resubmitted")
+	ErrTenderNotSealed      = errors.New("tender is not sealed")
+	ErrTenderOpened         = errors.New("bids of sealed tender are already opened")
+	ErrDeadlineEarlier      = errors.New("deadline of sealed tender cant be moved earlier")
+	ErrBatchRolledBack      = errors.New("item is not applied because other item of atomic batch failed")
+)
+
+var (
+	ErrAttachmentEmpty    = errors.New("attachment file is empty")
+	ErrAttachmentTooLarge = errors.New("attachment file is too large")
+	ErrAttachmentType     = errors.New("attachment file type is not allowed")
+	ErrAttachmentLimit    = errors.New("too many attachments")
 )
@@ -0,0 +1,50 @@
+package entity
+
+type FilterOperator string
+
+const (
+	OpEq    FilterOperator = "=="
+	OpNotEq FilterOperator = "!="
+	OpGt    FilterOperator = ">"
+	OpGte   FilterOperator = ">="
+	OpLt    FilterOperator = "<"
+	OpLte   FilterOperator = "<="
+	OpIn    FilterOperator = "=in="
+	OpNotIn FilterOperator = "=out="
+)
+
+var FilterOperatorList = []FilterOperator{OpEq, OpNotEq, OpGte, OpLte, OpGt, OpLt, OpIn, OpNotIn}
+
+// Field is a db column name, it must be taken only from whitelist.
+type FilterCond struct {
+	Field  string
+	Op     FilterOperator
+	Values []any
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type ListQuery struct {
+	Filters []FilterCond
+	Sort    []SortField
+}
+
+func (q *ListQuery) SortOr(fallback ...SortField) []SortField {
+	if len(q.Sort) == 0 {
+		return fallback
+	}
+	return q.Sort
+}
+
+// ExportFormat is format of list export, lists are exported whole without paging
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+)
+
+var ExportFormatList = []ExportFormat{ExportCSV, ExportNDJSON}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageSide is party of bid conversation: tender organization or bid author
+type MessageSide string
+
+const (
+	SideTender MessageSide = "Tender"
+	SideBidder MessageSide = "Bidder"
+)
+
+// BidMessage is message in private conversation about bid,
+// ReadAt is set when other side reads thread
+type BidMessage struct {
+	Id        uuid.UUID   `json:"id"`
+	BidID     uuid.UUID   `json:"bidId"`
+	Side      MessageSide `json:"side"`
+	Text      string      `json:"text"`
+	CreatedAt time.Time   `json:"createdAt"`
+	ReadAt    *time.Time  `json:"readAt,omitempty"`
+
+	ActorType ActorType `json:"authorType"`
+	ActorID   uuid.UUID `json:"authorId"`
+	// pseudonym of bidder shown to tender organization while evaluation of tender is anonymous
+	AuthorLabel string `json:"authorLabel,omitempty"`
+}
+
+func (m BidMessage) MarshalJSON() ([]byte, error) {
+	type Alias BidMessage
+	var readAt *string
+	if m.ReadAt != nil {
+		s := m.ReadAt.Format(time.RFC3339)
+		readAt = &s
+	}
+	var (
+		actorType *ActorType
+		actorID   *uuid.UUID
+	)
+	if m.AuthorLabel == "" {
+		actorType, actorID = &m.ActorType, &m.ActorID
+	}
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string     `json:"createdAt"`
+			ReadAt    *string    `json:"readAt,omitempty"`
+			ActorType *ActorType `json:"authorType,omitempty"`
+			ActorID   *uuid.UUID `json:"authorId,omitempty"`
+		}{
+			Alias:     (*Alias)(&m),
+			CreatedAt: m.CreatedAt.Format(time.RFC3339),
+			ReadAt:    readAt,
+			ActorType: actorType,
+			ActorID:   actorID,
+		},
+	)
+}
+
+// Anonymize replace author with pseudonym of bidder
+func (m *BidMessage) Anonymize(label string) {
+	m.ActorType = ""
+	m.ActorID = uuid.Nil
+	m.AuthorLabel = label
+}
+
+type MessageCount struct {
+	Unread int64 `json:"unread"`
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotifyBidDecision   NotificationType = "bid.decision"
+	NotifyBidReviewed   NotificationType = "bid.reviewed"
+	NotifyTenderUpdated NotificationType = "tender.updated"
+	NotifyTenderClosed  NotificationType = "tender.closed"
+	NotifyVoteRequired  NotificationType = "vote.required"
+	NotifyBidWithdrawn  NotificationType = "bid.withdrawn"
+	NotifyTenderOpened  NotificationType = "tender.opened"
+)
+
+var NotificationTypeList = []NotificationType{NotifyBidDecision, NotifyBidReviewed, NotifyTenderUpdated, NotifyTenderClosed, NotifyVoteRequired, NotifyBidWithdrawn, NotifyTenderOpened}
+
+// Notification is inbox record of user, data is payload of event it was made from
+type Notification struct {
+	Id        uuid.UUID        `json:"id"`
+	Type      NotificationType `json:"type"`
+	Data      json.RawMessage  `json:"data"`
+	ReadAt    *time.Time       `json:"readAt,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+
+	UserID  uuid.UUID `json:"-"`
+	EventID uuid.UUID `json:"-"`
+}
+
+func (n Notification) MarshalJSON() ([]byte, error) {
+	type Alias Notification
+	var readAt *string
+	if n.ReadAt != nil {
+		s := n.ReadAt.Format(time.RFC3339)
+		readAt = &s
+	}
+	return json.Marshal(
+		struct {
+			*Alias
+			ReadAt    *string `json:"readAt,omitempty"`
+			CreatedAt string  `json:"createdAt"`
+		}{
+			Alias:     (*Alias)(&n),
+			ReadAt:    readAt,
+			CreatedAt: n.CreatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+type NotificationCount struct {
+	Total  int64 `json:"total"`
+	Unread int64 `json:"unread"`
+}
+
+// NotificationPreference is whether user receives notifications of type, all types are enabled by default
+type NotificationPreference struct {
+	Type    NotificationType `json:"type"`
+	Enabled bool             `json:"enabled"`
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OpeningReason string
+
+const (
+	OpenedByDeadline OpeningReason = "Deadline"
+	OpenedByQuorum   OpeningReason = "Quorum"
+)
+
+var OpeningReasonList = []OpeningReason{OpenedByDeadline, OpenedByQuorum}
+
+// OpeningProtocol is record of sealed tender opening, it lists all bids revealed at that moment
+type OpeningProtocol struct {
+	Id       uuid.UUID     `json:"id"`
+	TenderID uuid.UUID     `json:"tenderId"`
+	Reason   OpeningReason `json:"reason"`
+	OpenedAt time.Time     `json:"openedAt"`
+	Bids     []OpenedBid   `json:"bids"`
+}
+
+func (p OpeningProtocol) MarshalJSON() ([]byte, error) {
+	type Alias OpeningProtocol
+	return json.Marshal(
+		struct {
+			*Alias
+			OpenedAt string `json:"openedAt"`
+		}{
+			Alias:    (*Alias)(&p),
+			OpenedAt: p.OpenedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+// OpenedBid is published bid as it was at opening
+type OpenedBid struct {
+	BidID       uuid.UUID     `json:"bidId"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	AuthorType  BidAuthorType `json:"authorType"`
+	AuthorID    uuid.UUID     `json:"authorId"`
+	Version     int           `json:"version"`
+	// pseudonym shown instead of author while evaluation of tender is anonymous
+	AuthorLabel string `json:"authorLabel,omitempty"`
+}
+
+func (b OpenedBid) MarshalJSON() ([]byte, error) {
+	type Alias OpenedBid
+	var (
+		authorType *BidAuthorType
+		authorID   *uuid.UUID
+	)
+	if b.AuthorLabel == "" {
+		authorType, authorID = &b.AuthorType, &b.AuthorID
+	}
+	return json.Marshal(
+		struct {
+			*Alias
+			AuthorType *BidAuthorType `json:"authorType,omitempty"`
+			AuthorID   *uuid.UUID     `json:"authorId,omitempty"`
+		}{
+			Alias:      (*Alias)(&b),
+			AuthorType: authorType,
+			AuthorID:   authorID,
+		},
+	)
+}
+
+// Anonymize replace author with pseudonym
+func (b *OpenedBid) Anonymize(label string) {
+	b.AuthorType = ""
+	b.AuthorID = uuid.Nil
+	b.AuthorLabel = label
+}
+
+// OpeningVotes is progress of opening sealed tender by responsibles
+type OpeningVotes struct {
+	Votes    int64      `json:"votes"`
+	Quorum   int        `json:"quorum"`
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+}
+
+func (v OpeningVotes) MarshalJSON() ([]byte, error) {
+	type Alias OpeningVotes
+	var openedAt *string
+	if v.OpenedAt != nil {
+		s := v.OpenedAt.Format(time.RFC3339)
+		openedAt = &s
+	}
+	return json.Marshal(
+		struct {
+			*Alias
+			OpenedAt *string `json:"openedAt,omitempty"`
+		}{
+			Alias:    (*Alias)(&v),
+			OpenedAt: openedAt,
+		},
+	)
+}
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InvitationStatusType string
+
+const (
+	InvitationPending  InvitationStatusType = "Pending"
+	InvitationAccepted InvitationStatusType = "Accepted"
+	InvitationDeclined InvitationStatusType = "Declined"
+	InvitationRevoked  InvitationStatusType = "Revoked"
+)
+
+var InvitationStatusTypeList = []InvitationStatusType{InvitationPending, InvitationAccepted, InvitationDeclined, InvitationRevoked}
+
+type OrganizationInvitation struct {
+	Id             uuid.UUID            `json:"id"`
+	OrganizationID uuid.UUID            `json:"organizationId"`
+	UserID         uuid.UUID            `json:"userId"`
+	InviterID      uuid.UUID            `json:"inviterId"`
+	Role           OrganizationRole     `json:"role"`
+	Status         InvitationStatusType `json:"status"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	UpdatedAt      time.Time            `json:"updatedAt"`
+}
+
+func (i OrganizationInvitation) MarshalJSON() ([]byte, error) {
+	type Alias OrganizationInvitation
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+			UpdatedAt string `json:"updatedAt"`
+		}{
+			Alias:     (*Alias)(&i),
+			CreatedAt: i.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: i.UpdatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+type OrganizationAuditAction string
+
+const (
+	AuditOrganizationCreated OrganizationAuditAction = "organization.created"
+	AuditOrganizationUpdated OrganizationAuditAction = "organization.updated"
+	AuditInvitationCreated   OrganizationAuditAction = "invitation.created"
+	AuditInvitationAccepted  OrganizationAuditAction = "invitation.accepted"
+	AuditInvitationDeclined  OrganizationAuditAction = "invitation.declined"
+	AuditInvitationRevoked   OrganizationAuditAction = "invitation.revoked"
+	AuditResponsibleRemoved  OrganizationAuditAction = "responsible.removed"
+	AuditResponsibleLeft     OrganizationAuditAction = "responsible.left"
+	AuditResponsibleRole     OrganizationAuditAction = "responsible.role_changed"
+	AuditApiKeyCreated       OrganizationAuditAction = "api_key.created"
+	AuditApiKeyRevoked       OrganizationAuditAction = "api_key.revoked"
+	AuditWebhookCreated      OrganizationAuditAction = "webhook.created"
+	AuditWebhookDeleted      OrganizationAuditAction = "webhook.deleted"
+)
+
+type OrganizationAudit struct {
+	Id             uuid.UUID               `json:"id"`
+	OrganizationID uuid.UUID               `json:"organizationId"`
+	ActorType      ActorType               `json:"actorType"`
+	ActorID        uuid.UUID               `json:"actorId"`
+	Action         OrganizationAuditAction `json:"action"`
+	TargetUserID   *uuid.UUID              `json:"targetUserId,omitempty"`
+	CreatedAt      time.Time               `json:"createdAt"`
+}
+
+func (a OrganizationAudit) MarshalJSON() ([]byte, error) {
+	type Alias OrganizationAudit
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+		}{
+			Alias:     (*Alias)(&a),
+			CreatedAt: a.CreatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+type OrganizationRole string
+
+const (
+	RoleOwner         OrganizationRole = "Owner"
+	RoleTenderManager OrganizationRole = "TenderManager"
+	RoleEvaluator     OrganizationRole = "Evaluator"
+	RoleViewer        OrganizationRole = "Viewer"
+)
+
+var OrganizationRoleList = []OrganizationRole{RoleOwner, RoleTenderManager, RoleEvaluator, RoleViewer}
+
+type Membership struct {
+	OrganizationID uuid.UUID        `json:"organizationId"`
+	UserID         uuid.UUID        `json:"userId"`
+	Role           OrganizationRole `json:"role"`
+}
+
+type OrganizationMember struct {
+	User User             `json:"user"`
+	Role OrganizationRole `json:"role"`
+}
+
+type UserMembership struct {
+	Organization Organization     `json:"organization"`
+	Role         OrganizationRole `json:"role"`
+}
+
+type UserProfile struct {
+	User          User             `json:"user"`
+	Email         string           `json:"email,omitempty"`
+	Locale        string           `json:"locale,omitempty"`
+	Organizations []UserMembership `json:"organizations"`
+}
+
+// OrganizationReview is bid author review of tender organization after bid is decided
+type OrganizationReview struct {
+	Id                  uuid.UUID `json:"id"`
+	OrganizationID      uuid.UUID `json:"organizationId"`
+	BidID               uuid.UUID `json:"bidId"`
+	PaymentDiscipline   int       `json:"paymentDiscipline"`
+	RequirementsClarity int       `json:"requirementsClarity"`
+	Fairness            int       `json:"fairness"`
+	Comment             string    `json:"comment,omitempty"`
+	CreatedAt           time.Time `json:"createdAt"`
+
+	ActorType ActorType `json:"-"`
+	ActorID   uuid.UUID `json:"-"`
+}
+
+func (r OrganizationReview) MarshalJSON() ([]byte, error) {
+	type Alias OrganizationReview
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+		}{
+			Alias:     (*Alias)(&r),
+			CreatedAt: r.CreatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+// OrganizationRating is summary of organization reviews, average is over all dimensions
+type OrganizationRating struct {
+	Count               int64    `json:"count"`
+	Average             *float64 `json:"average"`
+	PaymentDiscipline   *float64 `json:"paymentDiscipline"`
+	RequirementsClarity *float64 `json:"requirementsClarity"`
+	Fairness            *float64 `json:"fairness"`
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenderQuestion is clarification asked by bidder, answered questions are public and dont show who asked
+type TenderQuestion struct {
+	Id         uuid.UUID  `json:"id"`
+	TenderID   uuid.UUID  `json:"tenderId"`
+	Question   string     `json:"question"`
+	Answer     *string    `json:"answer,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	AnsweredAt *time.Time `json:"answeredAt,omitempty"`
+
+	AuthorID   uuid.UUID  `json:"-"`
+	AnswererID *uuid.UUID `json:"-"`
+}
+
+func (q TenderQuestion) MarshalJSON() ([]byte, error) {
+	type Alias TenderQuestion
+	var answeredAt *string
+	if q.AnsweredAt != nil {
+		s := q.AnsweredAt.Format(time.RFC3339)
+		answeredAt = &s
+	}
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt  string  `json:"createdAt"`
+			AnsweredAt *string `json:"answeredAt,omitempty"`
+		}{
+			Alias:      (*Alias)(&q),
+			CreatedAt:  q.CreatedAt.Format(time.RFC3339),
+			AnsweredAt: answeredAt,
+		},
+	)
+}
@@ -0,0 +1,18 @@
+package entity
+
+// StreamFilter narrow live event stream, empty lists mean no filter
+type StreamFilter struct {
+	EventTypes   []EventType
+	ServiceTypes []TenderServiceType
+}
+
+// StreamSubscription deliver live events visible to subscriber,
+// Events is closed when subscriber cant keep up and should reconnect with last event id
+type StreamSubscription struct {
+	Events <-chan Event
+	// missed events after requested last event id
+	Replay []Event
+	// set when missed events exceed replay limit, then replay is empty and client must reload state,
+	// stream continues after this seq
+	Reset *int64
+}
@@ -36,17 +36,66 @@ type Tender struct {
 	OrganizationID uuid.UUID         `json:"organizationId"`
 	Version        int               `json:"version"`
 	CreatedAt      time.Time         `json:"createdAt"`
+	// bids are accepted until deadline, questions until cutoff before it
+	Deadline *time.Time `json:"deadline,omitempty"`
+	// bids of sealed tender are hidden from tender organization until opening,
+	// it happens at deadline or earlier by quorum of responsibles
+	Sealed   bool       `json:"sealed"`
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+	// bid authors are hidden from tender organization until tender is closed
+	Anonymous bool `json:"anonymous"`
+
+	// attachments of this version, listed by separate endpoint
+	AttachmentIDs []uuid.UUID `json:"-"`
+
+	// rating of organization by bidders, set only in listings
+	OrganizationRating *OrganizationRating `json:"organizationRating,omitempty"`
+
+	// who made this version
+	ActorType ActorType  `json:"-"`
+	ActorID   *uuid.UUID `json:"-"`
 }
 
 func (t Tender) MarshalJSON() ([]byte, error) {
 	type Alias Tender
+	formatOpt := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		s := t.Format(time.RFC3339)
+		return &s
+	}
 	return json.Marshal(
 		struct {
 			*Alias
-			CreatedAt string `json:"createdAt"`
+			CreatedAt string  `json:"createdAt"`
+			Deadline  *string `json:"deadline,omitempty"`
+			OpenedAt  *string `json:"openedAt,omitempty"`
 		}{
 			Alias:     (*Alias)(&t),
 			CreatedAt: t.CreatedAt.Format(time.RFC3339),
+			Deadline:  formatOpt(t.Deadline),
+			OpenedAt:  formatOpt(t.OpenedAt),
 		},
 	)
 }
+
+// DeadlinePassed report if tender has deadline and it is before now
+func (t *Tender) DeadlinePassed(now time.Time) bool {
+	return t.Deadline != nil && !now.Before(*t.Deadline)
+}
+
+// BidsSealed report if bids of tender are still hidden from tender organization
+func (t *Tender) BidsSealed() bool {
+	return t.Sealed && t.OpenedAt == nil
+}
+
+// BidsOpened report if bids of sealed tender are revealed, submission is over after it
+func (t *Tender) BidsOpened() bool {
+	return t.Sealed && t.OpenedAt != nil
+}
+
+// AuthorsHidden report if bid authors are shown to tender organization by pseudonyms
+func (t *Tender) AuthorsHidden() bool {
+	return t.Anonymous && t.Status != Closed
+}
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTenderPublished EventType = "tender.published"
+	EventTenderUpdated   EventType = "tender.updated"
+	EventTenderClosed    EventType = "tender.closed"
+	EventTenderOpened    EventType = "tender.opened"
+	EventBidCreated      EventType = "bid.created"
+	EventBidPublished    EventType = "bid.published"
+	EventBidDecision     EventType = "bid.decision"
+	EventBidWithdrawn    EventType = "bid.withdrawn"
+	EventReviewCreated   EventType = "review.created"
+)
+
+var EventTypeList = []EventType{
+	EventTenderPublished, EventTenderUpdated, EventTenderClosed, EventTenderOpened,
+	EventBidCreated, EventBidPublished, EventBidDecision, EventBidWithdrawn, EventReviewCreated,
+}
+
+// Event is written to outbox in the same transaction as change it describes
+type Event struct {
+	Id        uuid.UUID       `json:"id"`
+	Type      EventType       `json:"type"`
+	Payload   json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"createdAt"`
+
+	// insert order, used as stream event id
+	Seq int64 `json:"-"`
+	// organizations whose webhooks receive event
+	OrganizationIDs []uuid.UUID `json:"-"`
+	ProcessedAt     *time.Time  `json:"-"`
+	Attempts        int         `json:"-"`
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	type Alias Event
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+		}{
+			Alias:     (*Alias)(&e),
+			CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+// BidDecisionEvent is payload of bid.decision
+type BidDecisionEvent struct {
+	Bid      Bid             `json:"bid"`
+	Decision BidDecisionType `json:"decision"`
+	UserID   uuid.UUID       `json:"userId"`
+}
+
+// ReviewCreatedEvent is payload of review.created
+type ReviewCreatedEvent struct {
+	BidID  uuid.UUID `json:"bidId"`
+	Review BidRewiew `json:"review"`
+}
+
+// Webhook is organization subscription to events, deliveries are signed with secret
+type Webhook struct {
+	Id             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	URL            string    `json:"url"`
+	Events         []string  `json:"events"`
+	CreatorID      uuid.UUID `json:"creatorId"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	Secret string `json:"-"`
+}
+
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	type Alias Webhook
+	return json.Marshal(
+		struct {
+			*Alias
+			CreatedAt string `json:"createdAt"`
+		}{
+			Alias:     (*Alias)(&w),
+			CreatedAt: w.CreatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+type DeliveryStatusType string
+
+const (
+	DeliveryPending   DeliveryStatusType = "Pending"
+	DeliverySucceeded DeliveryStatusType = "Succeeded"
+	DeliveryFailed    DeliveryStatusType = "Failed"
+)
+
+var DeliveryStatusTypeList = []DeliveryStatusType{DeliveryPending, DeliverySucceeded, DeliveryFailed}
+
+// WebhookDelivery is one event sent to one webhook with all its attempts
+type WebhookDelivery struct {
+	Id             uuid.UUID          `json:"id"`
+	WebhookID      uuid.UUID          `json:"webhookId"`
+	EventID        uuid.UUID          `json:"eventId"`
+	EventType      EventType          `json:"eventType"`
+	Status         DeliveryStatusType `json:"status"`
+	Attempts       int                `json:"attempts"`
+	ResponseStatus *int               `json:"responseStatus,omitempty"`
+	LastError      string             `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time         `json:"nextAttemptAt,omitempty"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	UpdatedAt      time.Time          `json:"updatedAt"`
+}
+
+func (d WebhookDelivery) MarshalJSON() ([]byte, error) {
+	type Alias WebhookDelivery
+	var nextAttemptAt *string
+	if d.NextAttemptAt != nil {
+		s := d.NextAttemptAt.Format(time.RFC3339)
+		nextAttemptAt = &s
+	}
+	return json.Marshal(
+		struct {
+			*Alias
+			NextAttemptAt *string `json:"nextAttemptAt,omitempty"`
+			CreatedAt     string  `json:"createdAt"`
+			UpdatedAt     string  `json:"updatedAt"`
+		}{
+			Alias:         (*Alias)(&d),
+			NextAttemptAt: nextAttemptAt,
+			CreatedAt:     d.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:     d.UpdatedAt.Format(time.RFC3339),
+		},
+	)
+}
+
+// DeliveryTask is claimed delivery with everything needed to send it
+type DeliveryTask struct {
+	Delivery WebhookDelivery
+	Webhook  Webhook
+	Event    Event
+}
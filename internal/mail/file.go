@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"avito/internal/config"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer write messages to maildir instead of sending, for local development and tests
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(cfg *config.Mail) (*FileMailer, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(cfg.Dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("create maildir: %w", err)
+		}
+	}
+
+	return &FileMailer{
+		dir:  cfg.Dir,
+		from: cfg.From,
+	}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg *Message) error {
+	data, err := formatMessage(m.from, msg)
+	if err != nil {
+		return fmt.Errorf("format message: %w", err)
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d.%s.eml", time.Now().UnixNano(), hex.EncodeToString(buf))
+
+	// maildir readers see only complete messages moved from tmp to new
+	tmpPath := filepath.Join(m.dir, "tmp", name)
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+
+	return os.Rename(tmpPath, filepath.Join(m.dir, "new", name))
+}
@@ -0,0 +1,82 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Templates is set of localized templates, each file templates/<locale>/<name>.tmpl defines "subject" and "body"
+type Templates struct {
+	locales       map[string]map[string]*template.Template
+	defaultLocale string
+}
+
+func LoadTemplates(defaultLocale string) (*Templates, error) {
+	files, err := fs.Glob(templatesFS, "templates/*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{
+		locales:       map[string]map[string]*template.Template{},
+		defaultLocale: defaultLocale,
+	}
+	for _, file := range files {
+		locale := path.Base(path.Dir(file))
+		name := strings.TrimSuffix(path.Base(file), ".tmpl")
+
+		tmpl, err := template.ParseFS(templatesFS, file)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", file, err)
+		}
+
+		if t.locales[locale] == nil {
+			t.locales[locale] = map[string]*template.Template{}
+		}
+		t.locales[locale][name] = tmpl
+	}
+
+	if t.locales[defaultLocale] == nil {
+		return nil, fmt.Errorf("no templates for default locale %q", defaultLocale)
+	}
+
+	return t, nil
+}
+
+func (t *Templates) Has(name string) bool {
+	_, ok := t.locales[t.defaultLocale][name]
+	return ok
+}
+
+// Render execute template in locale falling back to default one
+func (t *Templates) Render(locale string, name string, data any) (subject string, body string, err error) {
+	tmpl, ok := t.locales[locale][name]
+	if !ok {
+		tmpl, ok = t.locales[t.defaultLocale][name]
+	}
+	if !ok {
+		return "", "", fmt.Errorf("template %s not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", fmt.Errorf("render subject: %w", err)
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := tmpl.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", fmt.Errorf("render body: %w", err)
+	}
+	body = strings.TrimSpace(buf.String()) + "\n"
+
+	return subject, body, nil
+}
@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	apiKeyTag       = "avk_"
+	apiKeyPrefixLen = len(apiKeyTag) + 8
+)
+
+var defaultApiKeySort = entity.SortField{Field: "created_at", Desc: true}
+
+type ApiKeyUsecase struct {
+	apiKeyRepo    repos.ApiKeyRepo
+	orgRepo       repos.OrganizationRepo
+	tenderUsecase *TenderUsecase
+}
+
+func NewApiKeyUsecase(apiKeyRepo repos.ApiKeyRepo, orgRepo repos.OrganizationRepo, tenderUsecase *TenderUsecase) *ApiKeyUsecase {
+	return &ApiKeyUsecase{
+		apiKeyRepo:    apiKeyRepo,
+		orgRepo:       orgRepo,
+		tenderUsecase: tenderUsecase,
+	}
+}
+
+// CreateApiKey issue new key, secret is returned only here and stored as hash
+func (u *ApiKeyUsecase) CreateApiKey(ctx context.Context, username string, orgID uuid.UUID, name string, permissions []string) (*entity.IssuedApiKey, error) {
+	actor, err := u.checkManageApiKeys(ctx, username, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateApiKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate api key: %w", err)
+	}
+
+	key, err := u.apiKeyRepo.CreateApiKey(ctx, &entity.ApiKey{
+		OrganizationID: orgID,
+		Name:           name,
+		Prefix:         secret[:apiKeyPrefixLen],
+		Permissions:    permissions,
+		CreatorID:      actor.User.Id,
+		Hash:           hashApiKey(secret),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	return &entity.IssuedApiKey{ApiKey: *key, Key: secret}, nil
+}
+
+func (u *ApiKeyUsecase) GetApiKeys(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.ApiKey], error) {
+	if _, err := u.checkManageApiKeys(ctx, username, orgID); err != nil {
+		return nil, err
+	}
+
+	keys, err := u.apiKeyRepo.GetApiKeysPage(ctx, []entity.SortField{defaultApiKeySort}, *pag,
+		db.WithWhere("organization_id = ?", orgID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (u *ApiKeyUsecase) RevokeApiKey(ctx context.Context, username string, keyID uuid.UUID) (*entity.ApiKey, error) {
+	key, err := u.apiKeyRepo.GetApiKeyByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get api key by id: %w", err)
+	}
+
+	actor, err := u.checkManageApiKeys(ctx, username, key.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err = u.apiKeyRepo.RevokeApiKey(ctx, keyID, actor.User.Id)
+	if err != nil {
+		return nil, fmt.Errorf("revoke api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Authenticate find active key by its visible prefix and compare secret hash
+func (u *ApiKeyUsecase) Authenticate(ctx context.Context, secret string) (*entity.ApiKey, error) {
+	if !strings.HasPrefix(secret, apiKeyTag) || len(secret) <= apiKeyPrefixLen {
+		return nil, entity.ErrInvalidApiKey
+	}
+
+	key, err := u.apiKeyRepo.GetApiKeyByPrefix(ctx, secret[:apiKeyPrefixLen])
+	if err != nil {
+		if errors.Is(err, entity.ErrApiKeyNotFound) {
+			return nil, entity.ErrInvalidApiKey
+		}
+		return nil, fmt.Errorf("get api key by prefix: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashApiKey(secret))) != 1 || key.RevokedAt != nil {
+		return nil, entity.ErrInvalidApiKey
+	}
+
+	if err := u.apiKeyRepo.TouchApiKey(ctx, key.Id); err != nil {
+		return nil, fmt.Errorf("touch api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (u *ApiKeyUsecase) checkManageApiKeys(ctx context.Context, username string, orgID uuid.UUID) (*authz.Actor, error) {
+	if _, err := u.orgRepo.GetOrgByID(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("get org by id: %w", err)
+	}
+
+	actor, err := u.tenderUsecase.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	if err := u.tenderUsecase.authorize(ctx, actor, authz.ActionApiKeyManage, authz.OrganizationResource(orgID), entity.ErrUserPermissionOrg); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
+
+// generateApiKey return key in form avk_<8 hex prefix><48 hex secret>, prefix is stored as is to find key
+func generateApiKey() (string, error) {
+	buf := make([]byte, 28)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return apiKeyTag + hex.EncodeToString(buf), nil
+}
+
+// hashApiKey key is random and long enough, so fast hash is used instead of bcrypt to check it on every request
+func hashApiKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
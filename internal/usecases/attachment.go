@@ -0,0 +1,364 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	"avito/internal/config"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// sniffLen is how many bytes http.DetectContentType looks at
+const sniffLen = 512
+
+type AttachmentUsecase struct {
+	attachmentRepo repos.AttachmentRepo
+	blobStore      repos.BlobStore
+	tenderRepo     repos.TenderRepo
+	bidRepo        repos.BidRepo
+	tenderUsecase  *TenderUsecase
+	bidUsecase     *BidUsecase
+	cfg            config.Attachment
+}
+
+func NewAttachmentUsecase(
+	attachmentRepo repos.AttachmentRepo,
+	blobStore repos.BlobStore,
+	tenderRepo repos.TenderRepo,
+	bidRepo repos.BidRepo,
+	tenderUsecase *TenderUsecase,
+	bidUsecase *BidUsecase,
+	cfg *config.Attachment,
+) *AttachmentUsecase {
+	return &AttachmentUsecase{
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		tenderRepo:     tenderRepo,
+		bidRepo:        bidRepo,
+		tenderUsecase:  tenderUsecase,
+		bidUsecase:     bidUsecase,
+		cfg:            *cfg,
+	}
+}
+
+// AddTenderAttachment store file and make new tender version with it
+func (u *AttachmentUsecase) AddTenderAttachment(ctx context.Context, username string, tenderID uuid.UUID, upload *entity.Upload) (*entity.Attachment, error) {
+	actor, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := u.store(ctx, entity.AttachmentTender, tenderID, actor, upload)
+	if err != nil {
+		return nil, err
+	}
+	key := attachment.StorageKey
+
+	err = u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := u.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if len(tender.AttachmentIDs) >= u.cfg.MaxCount {
+			return entity.ErrAttachmentLimit
+		}
+
+		attachment, err = u.attachmentRepo.CreateAttachment(ctx, attachment)
+		if err != nil {
+			return fmt.Errorf("create attachment: %w", err)
+		}
+
+		return u.patchTenderAttachments(ctx, actor, tenderID, append(slices.Clone(tender.AttachmentIDs), attachment.Id))
+	})
+	if err != nil {
+		u.deleteBlob(ctx, key)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (u *AttachmentUsecase) GetTenderAttachments(ctx context.Context, username string, tenderID uuid.UUID) ([]entity.Attachment, error) {
+	if _, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderView); err != nil {
+		return nil, err
+	}
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+
+	return u.getAttachments(ctx, tender.AttachmentIDs)
+}
+
+// GetTenderAttachment open attachment of any tender version, so files of old versions stay available
+func (u *AttachmentUsecase) GetTenderAttachment(ctx context.Context, username string, tenderID uuid.UUID, attachmentID uuid.UUID) (*entity.Attachment, io.ReadCloser, error) {
+	if _, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderView); err != nil {
+		return nil, nil, err
+	}
+
+	return u.open(ctx, entity.AttachmentTender, tenderID, attachmentID)
+}
+
+// RemoveTenderAttachment make new tender version without attachment, file is kept for previous versions
+func (u *AttachmentUsecase) RemoveTenderAttachment(ctx context.Context, username string, tenderID uuid.UUID, attachmentID uuid.UUID) error {
+	actor, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderEdit)
+	if err != nil {
+		return err
+	}
+
+	return u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := u.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+
+		idx := slices.Index(tender.AttachmentIDs, attachmentID)
+		if idx < 0 {
+			return entity.ErrAttachmentNotFound
+		}
+
+		return u.patchTenderAttachments(ctx, actor, tenderID, slices.Delete(slices.Clone(tender.AttachmentIDs), idx, idx+1))
+	})
+}
+
+// AddBidAttachment store file and make new bid version with it
+func (u *AttachmentUsecase) AddBidAttachment(ctx context.Context, username string, bidID uuid.UUID, upload *entity.Upload) (*entity.Attachment, error) {
+	actor, _, err := u.bidUsecase.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := u.store(ctx, entity.AttachmentBid, bidID, actor, upload)
+	if err != nil {
+		return nil, err
+	}
+	key := attachment.StorageKey
+
+	err = u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := u.lockBid(ctx, bidID)
+		if err != nil {
+			return err
+		}
+		// opening protocol must match bid contents
+		if err := u.bidUsecase.checkNotOpened(ctx, bid); err != nil {
+			return err
+		}
+		if len(bid.AttachmentIDs) >= u.cfg.MaxCount {
+			return entity.ErrAttachmentLimit
+		}
+
+		attachment, err = u.attachmentRepo.CreateAttachment(ctx, attachment)
+		if err != nil {
+			return fmt.Errorf("create attachment: %w", err)
+		}
+
+		return u.patchBidAttachments(ctx, actor, bidID, append(slices.Clone(bid.AttachmentIDs), attachment.Id))
+	})
+	if err != nil {
+		u.deleteBlob(ctx, key)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (u *AttachmentUsecase) GetBidAttachments(ctx context.Context, username string, bidID uuid.UUID) ([]entity.Attachment, error) {
+	_, bid, err := u.bidUsecase.checkBidPermission(ctx, username, bidID, authz.ActionBidView, entity.ErrUserPermissionBid)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.getAttachments(ctx, bid.AttachmentIDs)
+}
+
+// GetBidAttachment open attachment of any bid version
+func (u *AttachmentUsecase) GetBidAttachment(ctx context.Context, username string, bidID uuid.UUID, attachmentID uuid.UUID) (*entity.Attachment, io.ReadCloser, error) {
+	if _, _, err := u.bidUsecase.checkBidPermission(ctx, username, bidID, authz.ActionBidView, entity.ErrUserPermissionBid); err != nil {
+		return nil, nil, err
+	}
+
+	return u.open(ctx, entity.AttachmentBid, bidID, attachmentID)
+}
+
+// RemoveBidAttachment make new bid version without attachment
+func (u *AttachmentUsecase) RemoveBidAttachment(ctx context.Context, username string, bidID uuid.UUID, attachmentID uuid.UUID) error {
+	actor, _, err := u.bidUsecase.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
+	if err != nil {
+		return err
+	}
+
+	return u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := u.lockBid(ctx, bidID)
+		if err != nil {
+			return err
+		}
+		if err := u.bidUsecase.checkNotOpened(ctx, bid); err != nil {
+			return err
+		}
+
+		idx := slices.Index(bid.AttachmentIDs, attachmentID)
+		if idx < 0 {
+			return entity.ErrAttachmentNotFound
+		}
+
+		return u.patchBidAttachments(ctx, actor, bidID, slices.Delete(slices.Clone(bid.AttachmentIDs), idx, idx+1))
+	})
+}
+
+// store sniff content type and stream upload to blob store counting size and checksum
+func (u *AttachmentUsecase) store(ctx context.Context, parentType entity.AttachmentParentType, parentID uuid.UUID, actor *authz.Actor, upload *entity.Upload) (*entity.Attachment, error) {
+	content := bufio.NewReaderSize(upload.Content, sniffLen)
+	head, err := content.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read upload: %w", err)
+	}
+	if len(head) == 0 {
+		return nil, entity.ErrAttachmentEmpty
+	}
+
+	// declared content type is not trusted
+	contentType := http.DetectContentType(head)
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(u.cfg.AllowedTypes, mediaType) {
+		return nil, entity.ErrAttachmentType
+	}
+
+	key := path.Join(string(parentType), parentID.String(), uuid.NewString())
+	hash := sha256.New()
+	size := &byteCounter{}
+
+	limited := io.LimitReader(content, u.cfg.MaxSize+1)
+	if err := u.blobStore.Put(ctx, key, io.TeeReader(limited, io.MultiWriter(hash, size))); err != nil {
+		return nil, fmt.Errorf("put blob: %w", err)
+	}
+	if size.n > u.cfg.MaxSize {
+		u.deleteBlob(ctx, key)
+		return nil, entity.ErrAttachmentTooLarge
+	}
+
+	ref := actor.Ref()
+	return &entity.Attachment{
+		Name:        upload.Name,
+		ContentType: contentType,
+		Size:        size.n,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		ParentType:  parentType,
+		ParentID:    parentID,
+		StorageKey:  key,
+		ActorType:   ref.Type,
+		ActorID:     ref.ID,
+	}, nil
+}
+
+func (u *AttachmentUsecase) open(ctx context.Context, parentType entity.AttachmentParentType, parentID uuid.UUID, attachmentID uuid.UUID) (*entity.Attachment, io.ReadCloser, error) {
+	attachment, err := u.attachmentRepo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get attachment by id: %w", err)
+	}
+	if attachment.ParentType != parentType || attachment.ParentID != parentID {
+		return nil, nil, entity.ErrAttachmentNotFound
+	}
+
+	content, err := u.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get blob: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+// getAttachments get attachments in order of ids
+func (u *AttachmentUsecase) getAttachments(ctx context.Context, ids []uuid.UUID) ([]entity.Attachment, error) {
+	if len(ids) == 0 {
+		return []entity.Attachment{}, nil
+	}
+
+	attachments, err := u.attachmentRepo.GetAttachmentsByFilter(ctx, db.WithWhere("id IN ?", ids))
+	if err != nil {
+		return nil, fmt.Errorf("get attachments: %w", err)
+	}
+
+	slices.SortFunc(attachments, func(a, b entity.Attachment) int {
+		return slices.Index(ids, a.Id) - slices.Index(ids, b.Id)
+	})
+
+	return attachments, nil
+}
+
+func (u *AttachmentUsecase) lockTender(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error) {
+	tenders, err := u.tenderRepo.GetTendersByFilter(ctx, db.WithWhere("id = ?", tenderID), db.WithLockForUpdate())
+	if err != nil {
+		return nil, fmt.Errorf("get tender: %w", err)
+	}
+	if len(tenders) == 0 {
+		return nil, entity.ErrTenderNotFound
+	}
+
+	return &tenders[0], nil
+}
+
+func (u *AttachmentUsecase) lockBid(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error) {
+	bids, err := u.bidRepo.GetBidsByFilter(ctx, db.WithWhere("id = ?", bidID), db.WithLockForUpdate())
+	if err != nil {
+		return nil, fmt.Errorf("get bid: %w", err)
+	}
+	if len(bids) == 0 {
+		return nil, entity.ErrBidNotFound
+	}
+
+	return &bids[0], nil
+}
+
+func (u *AttachmentUsecase) patchTenderAttachments(ctx context.Context, actor *authz.Actor, tenderID uuid.UUID, ids []uuid.UUID) error {
+	patch := &entity.Tender{AttachmentIDs: ids}
+	setTenderActor(patch, actor)
+
+	tender, err := u.tenderRepo.PatchTender(ctx, tenderID, patch)
+	if err != nil {
+		return fmt.Errorf("patch tender: %w", err)
+	}
+
+	return u.tenderUsecase.publishEvent(ctx, entity.EventTenderUpdated, tender, tender.OrganizationID)
+}
+
+func (u *AttachmentUsecase) patchBidAttachments(ctx context.Context, actor *authz.Actor, bidID uuid.UUID, ids []uuid.UUID) error {
+	ref := actor.Ref()
+	patch := &entity.Bid{AttachmentIDs: ids, ActorType: ref.Type, ActorID: &ref.ID}
+
+	if _, err := u.bidRepo.PatchBid(ctx, bidID, patch); err != nil {
+		return fmt.Errorf("patch bid: %w", err)
+	}
+
+	return nil
+}
+
+// deleteBlob clean up blob of failed upload, request ctx can be already canceled
+func (u *AttachmentUsecase) deleteBlob(ctx context.Context, key string) {
+	if err := u.blobStore.Delete(context.WithoutCancel(ctx), key); err != nil {
+		log.Printf("delete blob %s: %v", key, err)
+	}
+}
+
+type byteCounter struct {
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// runBatch apply items whose checks passed, each item in own transaction.
+// Atomic batch is applied in one transaction only if all checks passed and stops at first failure,
+// then other items are reported as rolled back
+func runBatch[T any](
+	ctx context.Context,
+	txManager repos.TxManager,
+	ids []uuid.UUID,
+	checks []error,
+	atomic bool,
+	apply func(ctx context.Context, i int) (*T, error),
+) ([]entity.BatchItem[T], error) {
+	items := make([]entity.BatchItem[T], len(ids))
+	for i, id := range ids {
+		items[i] = entity.BatchItem[T]{ID: id, Err: checks[i]}
+	}
+
+	if !atomic {
+		for i := range items {
+			if items[i].Err != nil {
+				continue
+			}
+			items[i].Item, items[i].Err = apply(ctx, i)
+		}
+		return items, nil
+	}
+
+	failed := slices.ContainsFunc(items, func(item entity.BatchItem[T]) bool { return item.Err != nil })
+	if !failed {
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			for i := range items {
+				items[i].Item, items[i].Err = apply(ctx, i)
+				if items[i].Err != nil {
+					failed = true
+					return items[i].Err
+				}
+			}
+			return nil
+		})
+		if err != nil && !failed {
+			return nil, err
+		}
+	}
+
+	if failed {
+		// applied items are rolled back with failed one, rest are not tried
+		for i := range items {
+			if items[i].Err == nil {
+				items[i] = entity.BatchItem[T]{ID: items[i].ID, Err: entity.ErrBatchRolledBack}
+			}
+		}
+	}
+
+	return items, nil
+}
@@ -1,31 +1,48 @@
 package usecases
 
 import (
+	"avito/internal/authz"
+	"avito/internal/config"
 	db "avito/internal/db/repos"
 	"avito/internal/entity"
 	"avito/internal/usecases/repos"
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	defaultBidSort        = entity.SortField{Field: "name"}
+	defaultFeedbackSort   = entity.SortField{Field: "description"}
+	defaultBidVersionSort = entity.SortField{Field: "version", Desc: true}
+	defaultSealedBidSort  = entity.SortField{Field: "created_at"}
+)
+
 type BidUsecase struct {
 	tenderRepo    repos.TenderRepo
 	bidRepo       repos.BidRepo
+	orgRepo       repos.OrganizationRepo
 	tenderUsecase *TenderUsecase
+	cfg           config.Bid
 }
 
 func NewBidUsecase(
 	tenderRepo repos.TenderRepo,
 	bidRepo repos.BidRepo,
+	orgRepo repos.OrganizationRepo,
 	tenderUsecase *TenderUsecase,
+	cfg *config.Bid,
 ) *BidUsecase {
 	return &BidUsecase{
 		tenderRepo:    tenderRepo,
 		bidRepo:       bidRepo,
+		orgRepo:       orgRepo,
 		tenderUsecase: tenderUsecase,
+		cfg:           *cfg,
 	}
 }
 
@@ -38,21 +55,27 @@ func (u *BidUsecase) CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bi
 	if tender.Status != entity.Published {
 		return nil, entity.ErrCreateBidTender
 	}
+	if tender.DeadlinePassed(time.Now()) {
+		return nil, entity.ErrTenderDeadlinePassed
+	}
+	if tender.BidsOpened() {
+		return nil, entity.ErrTenderOpened
+	}
 
-	users, err := u.tenderRepo.GetOrgUsersIDsByID(ctx, tender.OrganizationID)
+	voters, err := u.tenderUsecase.getVoters(ctx, tender.OrganizationID)
 	if err != nil {
-		return nil, fmt.Errorf("get org users: %w", err)
+		return nil, fmt.Errorf("get voters: %w", err)
 	}
-	bid.Kvorum = min(3, len(users))
+	bid.Kvorum = min(3, len(voters))
 
 	if bid.AuthorType == entity.AuthorUser {
-		_, err := u.tenderRepo.GetUserByID(ctx, bid.AuthorID)
+		_, err := u.tenderUsecase.getActiveUserByID(ctx, bid.AuthorID)
 		if err != nil {
 			return nil, fmt.Errorf("get user by id: %w", err)
 		}
 
 	} else {
-		_, err := u.tenderRepo.GetOrgByID(ctx, bid.AuthorID)
+		_, err := u.orgRepo.GetOrgByID(ctx, bid.AuthorID)
 		if err != nil {
 			return nil, fmt.Errorf("get org by id: %w", err)
 		}
@@ -60,26 +83,40 @@ func (u *BidUsecase) CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bi
 
 	bid.Version = 1
 	bid.Status = entity.BCreated
+	if bid.AuthorType == entity.AuthorUser {
+		bid.ActorType = entity.ActorUser
+		bid.ActorID = &bid.AuthorID
+	}
 
-	bid, err = u.bidRepo.CreateBid(ctx, bid)
+	err = u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		bid, err = u.bidRepo.CreateBid(ctx, bid)
+		if err != nil {
+			return fmt.Errorf("bid create: %w", err)
+		}
+
+		payload, err := u.eventBid(ctx, bid, tender)
+		if err != nil {
+			return err
+		}
+		return u.tenderUsecase.publishEvent(ctx, entity.EventBidCreated, payload, bidAudience(bid, tender)...)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("bid create: %w", err)
+		return nil, err
 	}
 
 	return bid, nil
 }
 
-func (u *BidUsecase) GetMyBids(ctx context.Context, username string, pag *entity.Pagination) ([]entity.Bid, error) {
-	user, orgsIDs, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
+func (u *BidUsecase) GetMyBids(ctx context.Context, username string, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Bid], error) {
+	actor, err := u.tenderUsecase.getActor(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("get user orgs ids: %w", err)
+		return nil, fmt.Errorf("get actor: %w", err)
 	}
 
-	bids, err := u.bidRepo.GetBidsByFilter(ctx,
-		db.WithWhere("author_id = ?", user.Id),
-		db.WithOr("author_id IN ?", orgsIDs),
-		db.WithOrder("name asc"),
-		db.WithPagination(*pag),
+	bids, err := u.bidRepo.GetBidsPage(ctx, query.SortOr(defaultBidSort), *pag,
+		db.WithWhere("author_id = ? OR author_id IN ?", actor.User.Id, actor.OrganizationIDs()),
+		db.WithFilters(query.Filters),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("get bids: %w", err)
@@ -88,100 +125,273 @@ func (u *BidUsecase) GetMyBids(ctx context.Context, username string, pag *entity
 	return bids, nil
 }
 
-func (u *BidUsecase) GetTenderBidsList(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) ([]entity.Bid, error) {
-	_, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+func (u *BidUsecase) GetTenderBidsList(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Bid], error) {
+	scope, err := u.tenderBidsScope(ctx, username, tenderID, query)
 	if err != nil {
-		return nil, fmt.Errorf("get tender by id: %w", err)
+		return nil, err
 	}
 
-	user, orgsIDs, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
+	bids, err := u.bidRepo.GetBidsPage(ctx, scope.sort, *pag, scope.filters...)
 	if err != nil {
-		return nil, fmt.Errorf("get user orgs ids: %w", err)
+		return nil, fmt.Errorf("get tender bids: %w", err)
 	}
 
-	opts := []db.FilterOption{}
+	for i := range bids.Items {
+		if err := u.presentTenderBid(ctx, scope, &bids.Items[i]); err != nil {
+			return nil, err
+		}
+	}
 
-	opts = append(opts, db.WithOr("author_id = ?", user.Id))
-	opts = append(opts, db.WithOr("author_id IN ?", orgsIDs))
+	return bids, nil
+}
 
-	ok, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID)
+// ExportTenderBids pass bids of tender to fn as GetTenderBidsList shows them, without paging, rows are streamed from db
+func (u *BidUsecase) ExportTenderBids(ctx context.Context, username string, tenderID uuid.UUID, query *entity.ListQuery, fn func(*entity.Bid) error) error {
+	scope, err := u.tenderBidsScope(ctx, username, tenderID, query)
 	if err != nil {
-		return nil, fmt.Errorf("check permissions: %w", err)
+		return err
 	}
-	if ok {
-		opts = append(opts, db.WithOr("status = ?", entity.BPublished))
+
+	err = u.bidRepo.StreamBids(ctx, scope.sort, func(bid *entity.Bid) error {
+		if err := u.presentTenderBid(ctx, scope, bid); err != nil {
+			return err
+		}
+		return fn(bid)
+	}, scope.filters...)
+	if err != nil {
+		return fmt.Errorf("stream tender bids: %w", err)
 	}
 
-	bids, err := u.bidRepo.GetBidsByFilter(ctx,
-		db.WithWhere("tender_id = ?", tenderID),
-		db.WithOrGroupFilters(opts, u.bidRepo),
-		db.WithOrder("name asc"),
-		db.WithPagination(*pag),
-	)
+	return nil
+}
+
+func (u *BidUsecase) GetBidStatus(ctx context.Context, username string, bidID uuid.UUID) (entity.BidStatusType, error) {
+	_, bid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidView, entity.ErrUserPermissionBid)
 	if err != nil {
-		return nil, fmt.Errorf("get tender bids: %w", err)
+		return "", err
 	}
-	if len(bids) == 0 {
-		return nil, entity.ErrUserPermissionBidsTender
+
+	return bid.Status, nil
+}
+
+func (u *BidUsecase) UpdateBidStatus(ctx context.Context, username string, bidID uuid.UUID, newStatus entity.BidStatusType) (*entity.Bid, error) {
+	_, bid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
+	if err != nil {
+		return nil, err
 	}
 
-	return bids, nil
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+
+	labels, err := u.tenderLabels(ctx, tender)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.setBidStatus(ctx, bid, tender, labels, newStatus)
 }
 
-func (u *BidUsecase) GetBidStatus(ctx context.Context, username string, bidID uuid.UUID) (entity.BidStatusType, error) {
-	bid, err := u.bidRepo.GetBidByID(ctx, bidID)
+// UpdateBidsStatus change status of bids in batch, atomic batch is applied all or nothing
+func (u *BidUsecase) UpdateBidsStatus(ctx context.Context, username string, bidIDs uuid.UUIDs, newStatus entity.BidStatusType, atomic bool) ([]entity.BatchItem[entity.Bid], error) {
+	_, targets, checks, err := u.checkBidsPermission(ctx, username, bidIDs, authz.ActionBidEdit, entity.ErrUserPermissionBid)
+	if err != nil {
+		return nil, err
+	}
+
+	return runBatch(ctx, u.tenderUsecase.txManager, bidIDs, checks, atomic, func(ctx context.Context, i int) (*entity.Bid, error) {
+		return u.setBidStatus(ctx, targets[i].bid, targets[i].tender, targets[i].labels, newStatus)
+	})
+}
+
+// setBidStatus change status of bid, labels are pseudonyms of tender authors from tenderLabels
+func (u *BidUsecase) setBidStatus(ctx context.Context, bid *entity.Bid, tender *entity.Tender, labels map[uuid.UUID]string, newStatus entity.BidStatusType) (*entity.Bid, error) {
+	// votes were cast for canceled bid, so it returns only through resubmit rules
+	if bid.Status == entity.BCanceled && newStatus != entity.BCanceled {
+		return nil, entity.ErrBidCanceled
+	}
+	wasPublished := bid.Status == entity.BPublished
+	// published bid has votes and leaves only by withdraw with its reason and deadline rules
+	if wasPublished && newStatus != entity.BPublished {
+		return nil, entity.ErrBidPublished
+	}
+
+	if newStatus == entity.BPublished && !wasPublished && tender.BidsOpened() {
+		return nil, entity.ErrTenderOpened
+	}
+	if newStatus == entity.BPublished && !wasPublished && tender.DeadlinePassed(time.Now()) {
+		return nil, entity.ErrTenderDeadlinePassed
+	}
+
+	bidID := bid.Id
+	err := u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.bidRepo.UpdateBidStatus(ctx, bidID, newStatus); err != nil {
+			return fmt.Errorf("update bid status by id: %w", err)
+		}
+
+		if newStatus == entity.BCanceled {
+			if err := u.bidRepo.UnshipsBid(ctx, bidID); err != nil {
+				return fmt.Errorf("unship bid: %w", err)
+			}
+		}
+
+		var err error
+		bid, err = u.bidRepo.GetBidByID(ctx, bidID)
+		if err != nil {
+			return fmt.Errorf("get bid by id: %w", err)
+		}
+
+		// published bid waits for votes of tender organization
+		if bid.Status != entity.BPublished || wasPublished {
+			return nil
+		}
+		return u.tenderUsecase.publishEvent(ctx, entity.EventBidPublished, labeledBid(nil, bid, labels), bidAudience(bid, tender)...)
+	})
 	if err != nil {
-		return "", fmt.Errorf("get bid by id: %w", err)
+		return nil, err
 	}
 
-	ok, err := u.checkUserOwnerBid(ctx, username, bidID)
+	return bid, nil
+}
+
+// WithdrawBid cancel published bid with reason, pending votes are cleared and tender organization is notified
+func (u *BidUsecase) WithdrawBid(ctx context.Context, username string, bidID uuid.UUID, reason string) (*entity.Bid, error) {
+	actor, bid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
 	if err != nil {
-		return "", fmt.Errorf("check user bid permission: %w", err)
+		return nil, err
 	}
-	if ok {
-		return bid.Status, nil
+
+	if bid.Status != entity.BPublished || bid.Decision != "" {
+		return nil, entity.ErrBidNotWithdrawable
 	}
 
-	ok, err = u.checkUserOwnerTenderByBid(ctx, username, bidID)
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
 	if err != nil {
-		return "", fmt.Errorf("check user bid permission: %w", err)
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+	if tender.DeadlinePassed(time.Now()) {
+		return nil, entity.ErrTenderDeadlinePassed
 	}
-	if ok && bid.Status == entity.BPublished {
-		return entity.BPublished, nil
+	if tender.BidsOpened() {
+		return nil, entity.ErrTenderOpened
 	}
 
-	return "", entity.ErrUserPermissionBid
+	err = u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.bidRepo.UnshipsBid(ctx, bidID); err != nil {
+			return fmt.Errorf("unship bid: %w", err)
+		}
+
+		var err error
+		bid, err = u.bidRepo.TransitBid(ctx, bidID, map[string]any{
+			"status":          entity.BCanceled,
+			"withdraw_reason": reason,
+		}, actor.Ref())
+		if err != nil {
+			return fmt.Errorf("withdraw bid: %w", err)
+		}
+
+		payload, err := u.eventBid(ctx, bid, tender)
+		if err != nil {
+			return err
+		}
+		return u.tenderUsecase.publishEvent(ctx, entity.EventBidWithdrawn, payload, bidAudience(bid, tender)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bid, nil
 }
 
-func (u *BidUsecase) UpdateBidStatus(ctx context.Context, username string, bidID uuid.UUID, newStatus entity.BidStatusType) (*entity.Bid, error) {
-	ok, err := u.checkUserOwnerBid(ctx, username, bidID)
+// ResubmitBid publish withdrawn bid again before deadline, number of resubmissions is limited
+func (u *BidUsecase) ResubmitBid(ctx context.Context, username string, bidID uuid.UUID) (*entity.Bid, error) {
+	actor, bid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
 	if err != nil {
-		return nil, fmt.Errorf("check user bid permission: %w", err)
+		return nil, err
+	}
+
+	if bid.Status != entity.BCanceled || bid.Decision != "" {
+		return nil, entity.ErrBidNotWithdrawn
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionBid
+	if bid.Resubmissions >= u.cfg.MaxResubmissions {
+		return nil, entity.ErrResubmitLimit
 	}
 
-	if err := u.bidRepo.UpdateBidStatus(ctx, bidID, newStatus); err != nil {
-		return nil, fmt.Errorf("update bid status by id: %w", err)
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+	if tender.Status != entity.Published {
+		return nil, entity.ErrResubmitBidTender
+	}
+	if tender.DeadlinePassed(time.Now()) {
+		return nil, entity.ErrTenderDeadlinePassed
+	}
+	if tender.BidsOpened() {
+		return nil, entity.ErrTenderOpened
 	}
 
-	bid, err := u.bidRepo.GetBidByID(ctx, bidID)
+	err = u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		bid, err = u.bidRepo.TransitBid(ctx, bidID, map[string]any{
+			"status":          entity.BPublished,
+			"withdraw_reason": "",
+			"resubmissions":   bid.Resubmissions + 1,
+		}, actor.Ref())
+		if err != nil {
+			return fmt.Errorf("resubmit bid: %w", err)
+		}
+
+		// resubmitted bid waits for votes again
+		payload, err := u.eventBid(ctx, bid, tender)
+		if err != nil {
+			return err
+		}
+		return u.tenderUsecase.publishEvent(ctx, entity.EventBidPublished, payload, bidAudience(bid, tender)...)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get bid by id: %w", err)
+		return nil, err
 	}
 
 	return bid, nil
 }
 
+// GetBidHistory get saved versions of bid newest first, including withdrawals and resubmissions
+func (u *BidUsecase) GetBidHistory(ctx context.Context, username string, bidID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.Bid], error) {
+	actor, bid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidView, entity.ErrUserPermissionBid)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+
+	versions, err := u.bidRepo.GetBidVersionsPage(ctx, bidID, []entity.SortField{defaultBidVersionSort}, *pag)
+	if err != nil {
+		return nil, fmt.Errorf("get bid versions: %w", err)
+	}
+
+	if err := u.hideAuthors(ctx, actor, tender, versions.Items); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
 func (u *BidUsecase) PatchBid(ctx context.Context, username string, bidID uuid.UUID, bid *entity.Bid) (*entity.Bid, error) {
-	ok, err := u.checkUserOwnerBid(ctx, username, bidID)
+	actor, currBid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
 	if err != nil {
-		return nil, fmt.Errorf("check user bid permission: %w", err)
+		return nil, err
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionBid
+	if err := u.checkNotOpened(ctx, currBid); err != nil {
+		return nil, err
 	}
+	ref := actor.Ref()
+	bid.ActorType = ref.Type
+	bid.ActorID = &ref.ID
 
 	bid, err = u.bidRepo.PatchBid(ctx, bidID, bid)
 	if err != nil {
@@ -192,80 +402,135 @@ func (u *BidUsecase) PatchBid(ctx context.Context, username string, bidID uuid.U
 }
 
 func (u *BidUsecase) SubmitDecision(ctx context.Context, username string, bidID uuid.UUID, decision entity.BidDecisionType) (*entity.Bid, error) {
-	ok, err := u.checkUserOwnerTenderByBid(ctx, username, bidID)
+	actor, bid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidVote, entity.ErrUserPermissionShipBid)
 	if err != nil {
-		return nil, fmt.Errorf("check user owner tender by bid: %w", err)
+		return nil, err
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionShipBid
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
 	}
 
-	bid, err := u.bidRepo.GetBidByID(ctx, bidID)
+	labels, err := u.tenderLabels(ctx, tender)
 	if err != nil {
-		return nil, fmt.Errorf("get bid by id: %w", err)
+		return nil, err
 	}
 
+	return u.decide(ctx, actor, bid, tender, labels, decision)
+}
+
+// SubmitDecisions vote for bids in batch, atomic batch is applied all or nothing
+func (u *BidUsecase) SubmitDecisions(ctx context.Context, username string, bidIDs uuid.UUIDs, decision entity.BidDecisionType, atomic bool) ([]entity.BatchItem[entity.Bid], error) {
+	actor, targets, checks, err := u.checkBidsPermission(ctx, username, bidIDs, authz.ActionBidVote, entity.ErrUserPermissionShipBid)
+	if err != nil {
+		return nil, err
+	}
+
+	return runBatch(ctx, u.tenderUsecase.txManager, bidIDs, checks, atomic, func(ctx context.Context, i int) (*entity.Bid, error) {
+		return u.decide(ctx, actor, targets[i].bid, targets[i].tender, targets[i].labels, decision)
+	})
+}
+
+// decide vote for bid, labels are pseudonyms of tender authors from tenderLabels
+func (u *BidUsecase) decide(ctx context.Context, actor *authz.Actor, bid *entity.Bid, tender *entity.Tender, labels map[uuid.UUID]string, decision entity.BidDecisionType) (*entity.Bid, error) {
 	if bid.Status != entity.BPublished {
 		return nil, entity.ErrShipBidTender
 	}
 
-	if decision == entity.Rejected {
-		bid.Status = entity.BCanceled
-		bid.ShipsCount = 0
+	bidID := bid.Id
+	err := u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if decision == entity.Rejected {
+			bid.Status = entity.BCanceled
+			bid.ShipsCount = 0
 
-		if err := u.bidRepo.UnshipsBid(ctx, bidID); err != nil {
-			return nil, fmt.Errorf("unship bid: %w", err)
-		}
+			if err := u.bidRepo.UnshipsBid(ctx, bidID); err != nil {
+				return fmt.Errorf("unship bid: %w", err)
+			}
 
-		err := u.bidRepo.UpdateBidStatus(ctx, bidID, entity.BCanceled)
-		if err != nil {
-			return nil, fmt.Errorf("update bid to approved: %w", err)
-		}
+			err := u.bidRepo.UpdateBidStatus(ctx, bidID, entity.BCanceled)
+			if err != nil {
+				return fmt.Errorf("update bid to approved: %w", err)
+			}
 
-		return bid, nil
-	} else {
-		user, _, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
-		if err != nil {
-			return nil, fmt.Errorf("get user")
-		}
+			bid.Decision = entity.Rejected
+			if err := u.bidRepo.SetBidDecision(ctx, bidID, entity.Rejected); err != nil {
+				return fmt.Errorf("set bid decision: %w", err)
+			}
+		} else {
+			shipped, err := u.bidRepo.ShipBid(ctx, actor.User.Id, bidID)
+			if err != nil {
+				return fmt.Errorf("ship bid: %w", err)
+			}
 
-		shipped, err := u.bidRepo.ShipBid(ctx, user.Id, bidID)
-		if err != nil {
-			return nil, fmt.Errorf("ship bid: %w", err)
+			if shipped {
+				bid.ShipsCount += 1
+			}
 		}
 
-		if shipped {
-			bid.ShipsCount += 1
-		}
+		// tender is closed by subscriber when quorum is reached
+		return u.tenderUsecase.publishEvent(ctx, entity.EventBidDecision, entity.BidDecisionEvent{
+			Bid:      *labeledBid(nil, bid, labels),
+			Decision: decision,
+			UserID:   actor.User.Id,
+		}, bidAudience(bid, tender)...)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if bid.ShipsCount >= bid.Kvorum {
-			// err := u.bidRepo.UpdateBidStatus(ctx, bidID, entity.BApproved)
-			// if err != nil {
-			// 	return nil, fmt.Errorf("update bid to approved: %w", err)
-			// }
-			// bid.Status = entity.BApproved
+	return labeledBid(actor, bid, labels), nil
+}
 
-			if err := u.tenderRepo.UpdateTenderStatus(ctx, bid.TenderID, entity.Closed); err != nil {
-				return nil, fmt.Errorf("update tender status by id: %w", err)
-			}
-		}
+// CloseTenderOnQuorum is bid.decision subscriber, it closes tender when approved bid got quorum of votes
+func (u *BidUsecase) CloseTenderOnQuorum(ctx context.Context, event *entity.Event) error {
+	var decision entity.BidDecisionEvent
+	if err := json.Unmarshal(event.Payload, &decision); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", err)
+	}
+	if decision.Decision != entity.Approved {
+		return nil
+	}
 
-		return bid, nil
+	// votes count in payload may be stale, so bid is reloaded
+	bid, err := u.bidRepo.GetBidByID(ctx, decision.Bid.Id)
+	if err != nil {
+		return fmt.Errorf("get bid by id: %w", err)
 	}
-}
+	if bid.ShipsCount < bid.Kvorum {
+		return nil
+	}
+
+	if err := u.bidRepo.SetBidDecision(ctx, bid.Id, entity.Approved); err != nil {
+		return fmt.Errorf("set bid decision: %w", err)
+	}
+
+	// err := u.bidRepo.UpdateBidStatus(ctx, bidID, entity.BApproved)
+	// if err != nil {
+	// 	return nil, fmt.Errorf("update bid to approved: %w", err)
+	// }
+	// bid.Status = entity.BApproved
 
-func (u *BidUsecase) FeedbackBid(ctx context.Context, username string, bidID uuid.UUID, bidFeedback string) (*entity.Bid, error) {
-	ok, err := u.checkUserOwnerTenderByBid(ctx, username, bidID)
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
 	if err != nil {
-		return nil, fmt.Errorf("check user owner tender by bid: %w", err)
+		return fmt.Errorf("get tender by id: %w", err)
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionBid
+	if tender.Status == entity.Closed {
+		return nil
 	}
 
-	bid, err := u.bidRepo.GetBidByID(ctx, bidID)
+	if err := u.tenderRepo.UpdateTenderStatus(ctx, tender.Id, entity.Closed); err != nil {
+		return fmt.Errorf("update tender status by id: %w", err)
+	}
+	tender.Status = entity.Closed
+
+	return u.tenderUsecase.publishTenderStatus(ctx, tender)
+}
+
+func (u *BidUsecase) FeedbackBid(ctx context.Context, username string, bidID uuid.UUID, bidFeedback string, rating entity.ReviewRating) (*entity.Bid, error) {
+	actor, bid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidReview, entity.ErrUserPermissionBid)
 	if err != nil {
-		return nil, fmt.Errorf("get bid by id: %w", err)
+		return nil, err
 	}
 
 	if bid.ShipsCount < bid.Kvorum {
@@ -275,28 +540,66 @@ func (u *BidUsecase) FeedbackBid(ctx context.Context, username string, bidID uui
 	// 	return nil, entity.ErrUserPermissionRewiew
 	// }
 
-	_, err = u.bidRepo.CreateFeedback(ctx, &entity.BidRewiew{
-		Description: bidFeedback,
-		BidID:       bidID,
-	})
+	ref := actor.Ref()
+	reviews, err := u.bidRepo.GetFeedbacksByFilter(ctx,
+		db.WithWhere("bid_id = ?", bidID),
+		db.WithWhere("actor_id = ?", ref.ID),
+		db.WithWhere("deleted_at IS NULL"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get feedbacks: %w", err)
+	}
+	if len(reviews) != 0 {
+		return nil, entity.ErrReviewExists
+	}
 
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
 	if err != nil {
-		return nil, fmt.Errorf("create feedback: %w", err)
+		return nil, fmt.Errorf("get tender by id: %w", err)
 	}
 
-	return bid, nil
+	err = u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		review, err := u.bidRepo.CreateFeedback(ctx, &entity.BidRewiew{
+			Description:      bidFeedback,
+			Quality:          rating.Quality,
+			Timeliness:       rating.Timeliness,
+			Communication:    rating.Communication,
+			ModerationStatus: entity.ReviewVisible,
+			BidID:            bidID,
+			ActorType:        ref.Type,
+			ActorID:          &ref.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("create feedback: %w", err)
+		}
+
+		return u.tenderUsecase.publishEvent(ctx, entity.EventReviewCreated, entity.ReviewCreatedEvent{
+			BidID:  bidID,
+			Review: *review,
+		}, bidAudience(bid, tender)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bids := []entity.Bid{*bid}
+	if err := u.hideAuthors(ctx, actor, tender, bids); err != nil {
+		return nil, err
+	}
+
+	return &bids[0], nil
 }
 
 func (u *BidUsecase) RollbackBid(ctx context.Context, username string, bidID uuid.UUID, version int) (*entity.Bid, error) {
-	ok, err := u.checkUserOwnerBid(ctx, username, bidID)
+	actor, currBid, err := u.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
 	if err != nil {
-		return nil, fmt.Errorf("check user owner bid: %w", err)
+		return nil, err
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionBid
+	if err := u.checkNotOpened(ctx, currBid); err != nil {
+		return nil, err
 	}
 
-	bid, err := u.bidRepo.RollbackBid(ctx, bidID, version)
+	bid, err := u.bidRepo.RollbackBid(ctx, bidID, version, actor.Ref())
 	if err != nil {
 		return nil, fmt.Errorf("rollback bid: %w", err)
 	}
@@ -304,18 +607,46 @@ func (u *BidUsecase) RollbackBid(ctx context.Context, username string, bidID uui
 	return bid, nil
 }
 
-func (u *BidUsecase) CheckPrevFeedbacks(ctx context.Context, tenderID uuid.UUID, author string, requester string, pag entity.Pagination) ([]entity.BidRewiew, error) {
-	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+func (u *BidUsecase) CheckPrevFeedbacks(ctx context.Context, tenderID uuid.UUID, author string, requester string, pag entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.BidRewiew], error) {
+	filters, err := u.prevFeedbacksFilters(ctx, tenderID, author, requester, query)
 	if err != nil {
-		return nil, fmt.Errorf("get tender by id: %w", err)
+		return nil, err
+	}
+
+	feedbacks, err := u.bidRepo.GetFeedbacksPage(ctx, query.SortOr(defaultFeedbackSort), pag, filters...)
+	if err != nil {
+		return nil, fmt.Errorf("get feedbacks: %w", err)
+	}
+
+	return feedbacks, nil
+}
+
+// ExportPrevFeedbacks pass reviews of author bids to fn as CheckPrevFeedbacks lists them, without paging, rows are streamed from db
+func (u *BidUsecase) ExportPrevFeedbacks(ctx context.Context, tenderID uuid.UUID, author string, requester string, query *entity.ListQuery, fn func(*entity.BidRewiew) error) error {
+	filters, err := u.prevFeedbacksFilters(ctx, tenderID, author, requester, query)
+	if err != nil {
+		return err
+	}
+
+	if err := u.bidRepo.StreamFeedbacks(ctx, query.SortOr(defaultFeedbackSort), fn, filters...); err != nil {
+		return fmt.Errorf("stream feedbacks: %w", err)
 	}
+	return nil
+}
 
-	ok, err := u.tenderUsecase.checkPermissionForTender(ctx, requester, tender.Id)
+// prevFeedbacksFilters select visible reviews of author bids for responsible of tender the author bid to
+func (u *BidUsecase) prevFeedbacksFilters(ctx context.Context, tenderID uuid.UUID, author string, requester string, query *entity.ListQuery) ([]db.FilterOption, error) {
+	if _, err := u.tenderUsecase.checkPermissionForTender(ctx, requester, tenderID, authz.ActionReviewView); err != nil {
+		return nil, err
+	}
+
+	// lookup by author would tell evaluators who took part
+	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
 	if err != nil {
-		return nil, fmt.Errorf("check user permission: %w", err)
+		return nil, fmt.Errorf("get tender by id: %w", err)
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionTender
+	if tender.AuthorsHidden() {
+		return nil, entity.ErrBiddersHidden
 	}
 
 	authorEnt, authorOrgsIDs, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, author)
@@ -346,55 +677,309 @@ func (u *BidUsecase) CheckPrevFeedbacks(ctx context.Context, tenderID uuid.UUID,
 		bidsIds = append(bidsIds, b.Id)
 	}
 
-	feedbacks, err := u.bidRepo.GetFeedbacksByFilter(ctx,
+	return []db.FilterOption{
 		db.WithWhere("bid_id IN ?", bidsIds),
-		db.WithOrder("description asc"),
-		db.WithPagination(pag),
-	)
+		db.WithWhere("deleted_at IS NULL"),
+		db.WithWhere("moderation_status = ?", entity.ReviewVisible),
+		db.WithFilters(query.Filters),
+	}, nil
+}
+
+// tenderBidsScope is how bids of tender are listed to actor
+type tenderBidsScope struct {
+	actor   *authz.Actor
+	tender  *entity.Tender
+	sort    []entity.SortField
+	filters []db.FilterOption
+	// bids of other authors are sealed for actor
+	sealed bool
+	// pseudonyms of authors, nil if authors are not hidden
+	labels map[uuid.UUID]string
+}
+
+func (u *BidUsecase) tenderBidsScope(ctx context.Context, username string, tenderID uuid.UUID, query *entity.ListQuery) (*tenderBidsScope, error) {
+	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
 	if err != nil {
-		return nil, fmt.Errorf("get feedbacks: %w", err)
+		return nil, fmt.Errorf("get tender by id: %w", err)
 	}
 
-	return feedbacks, nil
+	actor, err := u.tenderUsecase.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	opts := []db.FilterOption{}
+
+	opts = append(opts, db.WithOr("author_id = ?", actor.User.Id))
+	opts = append(opts, db.WithOr("author_id IN ?", actor.OrganizationIDs()))
+
+	// published bids of tender are visible if policy allows to view any published bid of tender organization
+	ok, err := u.tenderUsecase.authorizer.Can(ctx, actor, authz.ActionBidView, authz.Resource{OrganizationID: tender.OrganizationID, Published: true})
+	if err != nil {
+		return nil, fmt.Errorf("check permissions: %w", err)
+	}
+	if ok {
+		opts = append(opts, db.WithOr("status = ?", entity.BPublished))
+	} else {
+		// others see only own bids, so list is for authors only
+		own, err := u.bidRepo.GetBidsByFilter(ctx,
+			db.WithWhere("tender_id = ?", tenderID),
+			db.WithOrGroupFilters(opts, u.bidRepo),
+			db.WithLimit(1),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("get own bids: %w", err)
+		}
+		if len(own) == 0 {
+			return nil, entity.ErrUserPermissionBidsTender
+		}
+	}
+
+	scope := &tenderBidsScope{actor: actor, tender: tender, sort: query.SortOr(defaultBidSort)}
+
+	// before opening of sealed tender its organization sees only that bids exist,
+	// so contents cant be probed by filter or sort either
+	if ok && tender.BidsSealed() {
+		canView, err := u.tenderUsecase.authorizer.Can(ctx, actor, authz.ActionBidView, authz.Resource{OrganizationID: tender.OrganizationID, Published: true, Sealed: true})
+		if err != nil {
+			return nil, fmt.Errorf("check permissions: %w", err)
+		}
+		scope.sealed = !canView
+	}
+	if scope.sealed {
+		if listQueried(query, "name") {
+			return nil, entity.ErrBidsSealed
+		}
+		scope.sort = query.SortOr(defaultSealedBidSort)
+	}
+	// same for authors of anonymous tender
+	if ok && tender.AuthorsHidden() && listQueried(query, "author_id", "author_type") {
+		return nil, entity.ErrBiddersHidden
+	}
+
+	scope.labels, err = u.tenderLabels(ctx, tender)
+	if err != nil {
+		return nil, err
+	}
+
+	scope.filters = []db.FilterOption{
+		db.WithWhere("tender_id = ?", tenderID),
+		db.WithOrGroupFilters(opts, u.bidRepo),
+		db.WithFilters(query.Filters),
+	}
+	return scope, nil
+}
+
+// presentTenderBid seal and anonymize bid of list for actor of scope
+func (u *BidUsecase) presentTenderBid(ctx context.Context, scope *tenderBidsScope, bid *entity.Bid) error {
+	if scope.sealed {
+		canView, err := u.tenderUsecase.authorizer.Can(ctx, scope.actor, authz.ActionBidView, authz.BidResource(bid, scope.tender))
+		if err != nil {
+			return fmt.Errorf("check permissions: %w", err)
+		}
+		if !canView {
+			bid.Seal()
+		}
+	}
+
+	*bid = *labeledBid(scope.actor, bid, scope.labels)
+	return nil
 }
 
-func (u *BidUsecase) checkUserOwnerTenderByBid(ctx context.Context, username string, bidID uuid.UUID) (bool, error) {
+// checkBidPermission authorize action with bid, actor and bid are returned to avoid reloading
+func (u *BidUsecase) checkBidPermission(ctx context.Context, username string, bidID uuid.UUID, action authz.Action, deny error) (*authz.Actor, *entity.Bid, error) {
 	bid, err := u.bidRepo.GetBidByID(ctx, bidID)
 	if err != nil {
-		return false, fmt.Errorf("get bid by id: %w", err)
+		return nil, nil, fmt.Errorf("get bid by id: %w", err)
 	}
 
 	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
 	if err != nil {
-		return false, fmt.Errorf("get tender by id: %w", err)
+		return nil, nil, fmt.Errorf("get tender by id: %w", err)
 	}
 
-	_, orgsIDs, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
+	actor, err := u.tenderUsecase.getActor(ctx, username)
 	if err != nil {
-		return false, fmt.Errorf("get user orgs ids: %w", err)
+		return nil, nil, fmt.Errorf("get actor: %w", err)
 	}
 
-	if slices.Contains(orgsIDs, tender.OrganizationID) {
-		return true, nil
+	if err := u.tenderUsecase.authorize(ctx, actor, action, authz.BidResource(bid, tender), deny); err != nil {
+		return nil, nil, err
 	}
 
-	return false, nil
+	return actor, bid, nil
 }
 
-func (u *BidUsecase) checkUserOwnerBid(ctx context.Context, username string, bidID uuid.UUID) (bool, error) {
-	bid, err := u.bidRepo.GetBidByID(ctx, bidID)
+type bidTarget struct {
+	bid    *entity.Bid
+	tender *entity.Tender
+	// pseudonyms of tender authors, loaded once per tender
+	labels map[uuid.UUID]string
+}
+
+// checkBidsPermission authorize action with each bid of batch, bids and tenders are loaded in two queries,
+// failed checks are returned by index of bid
+func (u *BidUsecase) checkBidsPermission(ctx context.Context, username string, bidIDs uuid.UUIDs, action authz.Action, deny error) (*authz.Actor, []bidTarget, []error, error) {
+	actor, err := u.tenderUsecase.getActor(ctx, username)
 	if err != nil {
-		return false, fmt.Errorf("get bid by id: %w", err)
+		return nil, nil, nil, fmt.Errorf("get actor: %w", err)
 	}
 
-	user, orgsIDs, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
+	bids, err := u.bidRepo.GetBidsByFilter(ctx, db.WithWhere("id IN ?", bidIDs))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get bids by ids: %w", err)
+	}
+	tenderIDs := uuid.UUIDs{}
+	for _, bid := range bids {
+		if !slices.Contains(tenderIDs, bid.TenderID) {
+			tenderIDs = append(tenderIDs, bid.TenderID)
+		}
+	}
+	tenders, err := u.tenderRepo.GetTendersByFilter(ctx, db.WithWhere("id IN ?", tenderIDs))
 	if err != nil {
-		return false, fmt.Errorf("get user orgs ids: %w", err)
+		return nil, nil, nil, fmt.Errorf("get tenders by ids: %w", err)
+	}
+
+	bidsByID := map[uuid.UUID]*entity.Bid{}
+	for i := range bids {
+		bidsByID[bids[i].Id] = &bids[i]
+	}
+	tendersByID := map[uuid.UUID]*entity.Tender{}
+	for i := range tenders {
+		tendersByID[tenders[i].Id] = &tenders[i]
+	}
+
+	// labels are loaded once per tender, not per bid
+	labelsByTender := map[uuid.UUID]map[uuid.UUID]string{}
+	for _, tender := range tendersByID {
+		labelsByTender[tender.Id], err = u.tenderLabels(ctx, tender)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
-	if user.Id == bid.AuthorID || slices.Contains(orgsIDs, bid.AuthorID) {
-		return true, nil
+	targets := make([]bidTarget, len(bidIDs))
+	checks := make([]error, len(bidIDs))
+	for i, bidID := range bidIDs {
+		bid, ok := bidsByID[bidID]
+		if !ok {
+			checks[i] = entity.ErrBidNotFound
+			continue
+		}
+		tender, ok := tendersByID[bid.TenderID]
+		if !ok {
+			checks[i] = entity.ErrTenderNotFound
+			continue
+		}
+
+		targets[i] = bidTarget{bid: bid, tender: tender, labels: labelsByTender[tender.Id]}
+		checks[i] = u.tenderUsecase.authorize(ctx, actor, action, authz.BidResource(bid, tender), deny)
 	}
 
-	return false, nil
+	return actor, targets, checks, nil
+}
+
+// checkNotOpened forbid changing bid contents after sealed tender is opened, protocol must match bids
+func (u *BidUsecase) checkNotOpened(ctx context.Context, bid *entity.Bid) error {
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return fmt.Errorf("get tender by id: %w", err)
+	}
+	if tender.BidsOpened() {
+		return entity.ErrTenderOpened
+	}
+	return nil
+}
+
+// bidAudience is organizations notified about bid: tender organization and author organization,
+// tender organization learns about bid from its publication, and about bids of sealed tender only from opening
+func bidAudience(bid *entity.Bid, tender *entity.Tender) []uuid.UUID {
+	orgIDs := []uuid.UUID{}
+	if bid.Status != entity.BCreated && !tender.BidsSealed() {
+		orgIDs = append(orgIDs, tender.OrganizationID)
+	}
+	if bid.AuthorType == entity.AuthorOrganization && !slices.Contains(orgIDs, bid.AuthorID) {
+		orgIDs = append(orgIDs, bid.AuthorID)
+	}
+	return orgIDs
+}
+
+// hideAuthors replace authors of bids with pseudonyms while evaluation of tender is anonymous,
+// bid authors still see themselves, nil actor is anyone e.g. event receiver
+func (u *BidUsecase) hideAuthors(ctx context.Context, actor *authz.Actor, tender *entity.Tender, bids []entity.Bid) error {
+	labels, err := u.tenderLabels(ctx, tender)
+	if err != nil {
+		return err
+	}
+	for i := range bids {
+		bids[i] = *labeledBid(actor, &bids[i], labels)
+	}
+	return nil
+}
+
+// eventBid is bid for event payload, it is delivered to tender organization too
+func (u *BidUsecase) eventBid(ctx context.Context, bid *entity.Bid, tender *entity.Tender) (*entity.Bid, error) {
+	labels, err := u.tenderLabels(ctx, tender)
+	if err != nil {
+		return nil, err
+	}
+	return labeledBid(nil, bid, labels), nil
+}
+
+// tenderLabels get pseudonyms of tender authors, nil while authors are not hidden
+func (u *BidUsecase) tenderLabels(ctx context.Context, tender *entity.Tender) (map[uuid.UUID]string, error) {
+	if !tender.AuthorsHidden() {
+		return nil, nil
+	}
+	return authorLabels(ctx, u.bidRepo, tender.Id)
+}
+
+// labeledBid is copy of bid as actor sees it with labels of its tender
+func labeledBid(actor *authz.Actor, bid *entity.Bid, labels map[uuid.UUID]string) *entity.Bid {
+	labeled := *bid
+	if labels != nil && (actor == nil || !isBidAuthor(actor, bid)) {
+		labeled.Anonymize(labels[bid.AuthorID])
+	}
+	return &labeled
+}
+
+// authorLabels give pseudonyms to bid authors of tender in order of their first bid
+func authorLabels(ctx context.Context, bidRepo repos.BidRepo, tenderID uuid.UUID) (map[uuid.UUID]string, error) {
+	bids, err := bidRepo.GetBidsByFilter(ctx,
+		db.WithWhere("tender_id = ?", tenderID),
+		db.WithOrder("created_at, id"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get tender bids: %w", err)
+	}
+
+	labels := map[uuid.UUID]string{}
+	for _, bid := range bids {
+		if _, ok := labels[bid.AuthorID]; !ok {
+			labels[bid.AuthorID] = fmt.Sprintf("Bidder %d", len(labels)+1)
+		}
+	}
+	return labels, nil
+}
+
+func isBidAuthor(actor *authz.Actor, bid *entity.Bid) bool {
+	if actor.ApiKey == nil && actor.User.Id == bid.AuthorID {
+		return true
+	}
+	return bid.AuthorType == entity.AuthorOrganization && slices.Contains(actor.OrganizationIDs(), bid.AuthorID)
+}
+
+// listQueried report if list query filters or sorts by any of columns
+func listQueried(query *entity.ListQuery, columns ...string) bool {
+	for _, f := range query.Filters {
+		if slices.Contains(columns, f.Field) {
+			return true
+		}
+	}
+	for _, f := range query.Sort {
+		if slices.Contains(columns, f.Field) {
+			return true
+		}
+	}
+	return false
 }
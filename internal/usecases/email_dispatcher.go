@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"avito/internal/config"
+	"avito/internal/entity"
+	"avito/internal/mail"
+	"avito/internal/usecases/repos"
+	"context"
+	"log"
+	"time"
+)
+
+const emailBatchSize = 20
+
+// EmailDispatcher send queued emails with retries, like webhook dispatcher
+type EmailDispatcher struct {
+	emailRepo repos.EmailRepo
+	mailer    mail.Mailer
+	cfg       config.Mail
+}
+
+func NewEmailDispatcher(emailRepo repos.EmailRepo, mailer mail.Mailer, cfg *config.Mail) *EmailDispatcher {
+	return &EmailDispatcher{
+		emailRepo: emailRepo,
+		mailer:    mailer,
+		cfg:       *cfg,
+	}
+}
+
+// Run poll emails until ctx is done
+func (d *EmailDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.dispatch(ctx); err != nil {
+				log.Printf("email dispatch: %v", err)
+			}
+		}
+	}
+}
+
+func (d *EmailDispatcher) dispatch(ctx context.Context) error {
+	// emails are sent one by one, so lease covers whole batch
+	emails, err := d.emailRepo.ClaimEmails(ctx, emailBatchSize, emailBatchSize*d.cfg.Timeout)
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		email := d.send(ctx, email)
+		if err := d.emailRepo.SaveEmailAttempt(ctx, email); err != nil {
+			log.Printf("save email %s: %v", email.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// send make one attempt and return email with its result and next attempt time
+func (d *EmailDispatcher) send(ctx context.Context, email entity.Email) *entity.Email {
+	email.Attempts++
+
+	sendCtx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+
+	err := d.mailer.Send(sendCtx, &mail.Message{To: email.To, Subject: email.Subject, Body: email.Body})
+	if err == nil {
+		email.Status = entity.DeliverySucceeded
+		email.LastError = ""
+		email.NextAttemptAt = nil
+		return &email
+	}
+
+	email.LastError = truncateError(err)
+
+	if email.Attempts >= d.cfg.MaxAttempts {
+		email.Status = entity.DeliveryFailed
+		email.NextAttemptAt = nil
+		return &email
+	}
+
+	next := time.Now().Add(d.cfg.RetryDelay << (email.Attempts - 1))
+	email.NextAttemptAt = &next
+
+	return &email
+}
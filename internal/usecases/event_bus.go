@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"avito/internal/config"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+)
+
+const eventBatchSize = 50
+
+// EventHandler react to domain event, it is called within transaction
+// together with handled mark, so its db changes are applied once
+type EventHandler func(ctx context.Context, event *entity.Event) error
+
+type eventSubscriber struct {
+	name   string
+	events []entity.EventType
+	handle EventHandler
+}
+
+// EventBus dispatch events from outbox to in-process subscribers with at-least-once semantics.
+// Event is processed when all its subscribers succeeded, failed ones are retried with backoff.
+type EventBus struct {
+	outboxRepo  repos.OutboxRepo
+	txManager   repos.TxManager
+	cfg         config.Events
+	subscribers []eventSubscriber
+}
+
+func NewEventBus(outboxRepo repos.OutboxRepo, txManager repos.TxManager, cfg *config.Events) *EventBus {
+	return &EventBus{
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+		cfg:        *cfg,
+	}
+}
+
+// Subscribe register handler for events, no events means all events.
+// Name is stored to skip already handled events on retry, so it must be stable.
+func (b *EventBus) Subscribe(name string, handle EventHandler, events ...entity.EventType) {
+	b.subscribers = append(b.subscribers, eventSubscriber{name: name, events: events, handle: handle})
+}
+
+// Run poll outbox until ctx is done
+func (b *EventBus) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.dispatch(ctx); err != nil {
+				log.Printf("event dispatch: %v", err)
+			}
+		}
+	}
+}
+
+func (b *EventBus) dispatch(ctx context.Context) error {
+	for {
+		events, err := b.outboxRepo.ClaimEvents(ctx, eventBatchSize, b.cfg.MaxAttempts, b.cfg.Lease)
+		if err != nil {
+			return fmt.Errorf("claim events: %w", err)
+		}
+
+		for _, event := range events {
+			if err := b.handle(ctx, &event); err != nil {
+				retryAt := time.Now().Add(b.cfg.RetryDelay << event.Attempts)
+				if err := b.outboxRepo.FailEvent(ctx, event.Id, truncateError(err), retryAt); err != nil {
+					return fmt.Errorf("fail event %s: %w", event.Id, err)
+				}
+				continue
+			}
+
+			if err := b.outboxRepo.MarkEventProcessed(ctx, event.Id); err != nil {
+				return fmt.Errorf("mark event %s processed: %w", event.Id, err)
+			}
+		}
+
+		if len(events) < eventBatchSize {
+			return nil
+		}
+	}
+}
+
+func (b *EventBus) handle(ctx context.Context, event *entity.Event) error {
+	for _, sub := range b.subscribers {
+		if len(sub.events) != 0 && !slices.Contains(sub.events, event.Type) {
+			continue
+		}
+
+		err := b.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			first, err := b.outboxRepo.MarkEventHandled(ctx, event.Id, sub.name)
+			if err != nil {
+				return fmt.Errorf("mark handled: %w", err)
+			}
+			if !first {
+				return nil
+			}
+
+			return sub.handle(ctx, event)
+		})
+		if err != nil {
+			return fmt.Errorf("subscriber %s: %w", sub.name, err)
+		}
+	}
+
+	return nil
+}
+
+func truncateError(err error) string {
+	text := err.Error()
+	if len(text) > maxErrorLen {
+		return text[:maxErrorLen]
+	}
+	return text
+}
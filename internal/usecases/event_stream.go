@@ -0,0 +1,356 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	streamBatchSize    = 100
+	streamBufferSize   = 64
+	streamReplayLimit  = 1000
+	streamPollInterval = time.Second
+	// seq is taken on insert, so event with lower seq can be committed later, gap is awaited this long
+	streamGapTimeout = 5 * time.Second
+)
+
+type streamSubscriber struct {
+	events   chan entity.Event
+	username string
+	actor    *authz.Actor
+	filter   entity.StreamFilter
+}
+
+// streamTarget is event resource resolved once for all subscribers
+type streamTarget struct {
+	action      authz.Action
+	resource    authz.Resource
+	serviceType entity.TenderServiceType
+}
+
+// EventStream broadcast committed outbox events to live subscribers of this instance.
+// Other instances are heard through postgres notifications, so each instance reads outbox itself.
+type EventStream struct {
+	outboxRepo    repos.OutboxRepo
+	tenderRepo    repos.TenderRepo
+	bidRepo       repos.BidRepo
+	apiKeyRepo    repos.ApiKeyRepo
+	listener      repos.EventListener
+	tenderUsecase *TenderUsecase
+
+	wake     chan struct{}
+	gapSince time.Time
+
+	mu          sync.Mutex
+	lastSeq     int64
+	subscribers map[*entity.StreamSubscription]*streamSubscriber
+}
+
+func NewEventStream(
+	outboxRepo repos.OutboxRepo,
+	tenderRepo repos.TenderRepo,
+	bidRepo repos.BidRepo,
+	apiKeyRepo repos.ApiKeyRepo,
+	listener repos.EventListener,
+	tenderUsecase *TenderUsecase,
+) *EventStream {
+	return &EventStream{
+		outboxRepo:    outboxRepo,
+		tenderRepo:    tenderRepo,
+		bidRepo:       bidRepo,
+		apiKeyRepo:    apiKeyRepo,
+		listener:      listener,
+		tenderUsecase: tenderUsecase,
+		wake:          make(chan struct{}, 1),
+		subscribers:   map[*entity.StreamSubscription]*streamSubscriber{},
+	}
+}
+
+// Run read new events on notification or poll interval until ctx is done
+func (s *EventStream) Run(ctx context.Context) {
+	// history before start is only available by replay
+	for {
+		seq, err := s.outboxRepo.GetLastEventSeq(ctx)
+		if err == nil {
+			s.mu.Lock()
+			s.lastSeq = seq
+			s.mu.Unlock()
+			break
+		}
+		log.Printf("event stream: get last seq: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(streamPollInterval):
+		}
+	}
+
+	go s.listener.Listen(ctx, s.notify)
+
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+
+		if err := s.poll(ctx); err != nil {
+			log.Printf("event stream: %v", err)
+		}
+	}
+}
+
+// Subscribe register subscriber, events after lastEventID up to now are returned as replay
+func (s *EventStream) Subscribe(ctx context.Context, username string, filter entity.StreamFilter, lastEventID *int64) (*entity.StreamSubscription, error) {
+	actor, err := s.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	events := make(chan entity.Event, streamBufferSize)
+	sub := &entity.StreamSubscription{Events: events, Replay: []entity.Event{}}
+	subscriber := &streamSubscriber{events: events, username: username, actor: actor, filter: filter}
+
+	s.mu.Lock()
+	s.subscribers[sub] = subscriber
+	// events up to until are already broadcasted, later ones come to channel
+	until := s.lastSeq
+	s.mu.Unlock()
+
+	if lastEventID == nil {
+		return sub, nil
+	}
+
+	missed, err := s.outboxRepo.GetEventsAfter(ctx, *lastEventID, streamReplayLimit+1)
+	if err != nil {
+		s.Unsubscribe(sub)
+		return nil, fmt.Errorf("get events after: %w", err)
+	}
+	if len(missed) > streamReplayLimit && missed[streamReplayLimit].Seq <= until {
+		// partial replay would look complete, so client is told to reload state instead
+		sub.Reset = &until
+		return sub, nil
+	}
+	for _, event := range missed {
+		if event.Seq > until {
+			break
+		}
+		target, err := s.resolve(ctx, &event)
+		if err != nil {
+			continue
+		}
+		if s.visible(ctx, subscriber, &event, target) {
+			sub.Replay = append(sub.Replay, event)
+		}
+	}
+
+	return sub, nil
+}
+
+// Refresh resolve actor of subscriber again, so membership changes apply to open stream,
+// error means actor lost access, e.g. user is deactivated or key is revoked
+func (s *EventStream) Refresh(ctx context.Context, sub *entity.StreamSubscription) error {
+	s.mu.Lock()
+	subscriber, ok := s.subscribers[sub]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	actor, err := s.getActor(ctx, subscriber.username)
+	if err != nil {
+		return fmt.Errorf("get actor: %w", err)
+	}
+
+	s.mu.Lock()
+	subscriber.actor = actor
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *EventStream) Unsubscribe(sub *entity.StreamSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if subscriber, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(subscriber.events)
+	}
+}
+
+func (s *EventStream) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *EventStream) poll(ctx context.Context) error {
+	for {
+		events, err := s.outboxRepo.GetEventsAfter(ctx, s.lastSeq, streamBatchSize)
+		if err != nil {
+			return fmt.Errorf("get events after: %w", err)
+		}
+
+		for _, event := range events {
+			if event.Seq != s.lastSeq+1 {
+				// rolled back transactions leave gaps forever, so gap is skipped after timeout
+				if s.gapSince.IsZero() {
+					s.gapSince = time.Now()
+				}
+				if time.Since(s.gapSince) < streamGapTimeout {
+					return nil
+				}
+			}
+			s.gapSince = time.Time{}
+
+			s.broadcast(ctx, &event)
+		}
+
+		if len(events) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *EventStream) broadcast(ctx context.Context, event *entity.Event) {
+	target, err := s.resolve(ctx, event)
+	if err != nil {
+		log.Printf("event stream: resolve event %s: %v", event.Id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastSeq = event.Seq
+	if target == nil {
+		return
+	}
+
+	for sub, subscriber := range s.subscribers {
+		if !s.visible(ctx, subscriber, event, target) {
+			continue
+		}
+
+		select {
+		case subscriber.events <- *event:
+		default:
+			// slow subscriber is dropped, it resumes from last received event
+			delete(s.subscribers, sub)
+			close(subscriber.events)
+		}
+	}
+}
+
+// getActor is tender usecase actor, but key is loaded again as one in context is from stream start
+func (s *EventStream) getActor(ctx context.Context, username string) (*authz.Actor, error) {
+	key := authz.ApiKeyFromContext(ctx)
+	if key == nil {
+		return s.tenderUsecase.getActor(ctx, username)
+	}
+
+	key, err := s.apiKeyRepo.GetApiKeyByID(ctx, key.Id)
+	if err != nil {
+		return nil, fmt.Errorf("get api key by id: %w", err)
+	}
+	if key.RevokedAt != nil {
+		return nil, entity.ErrInvalidApiKey
+	}
+	return &authz.Actor{ApiKey: key}, nil
+}
+
+// resolve find what subscriber must be allowed to see event
+func (s *EventStream) resolve(ctx context.Context, event *entity.Event) (*streamTarget, error) {
+	var bid entity.Bid
+
+	switch event.Type {
+	case entity.EventTenderPublished, entity.EventTenderUpdated, entity.EventTenderClosed:
+		var tender entity.Tender
+		if err := json.Unmarshal(event.Payload, &tender); err != nil {
+			return nil, fmt.Errorf("unmarshal tender: %w", err)
+		}
+		return &streamTarget{action: authz.ActionTenderView, resource: authz.TenderResource(&tender), serviceType: tender.ServiceType}, nil
+
+	case entity.EventTenderOpened:
+		var protocol entity.OpeningProtocol
+		if err := json.Unmarshal(event.Payload, &protocol); err != nil {
+			return nil, fmt.Errorf("unmarshal protocol: %w", err)
+		}
+		tender, err := s.tenderRepo.GetTenderByID(ctx, protocol.TenderID)
+		if err != nil {
+			return nil, fmt.Errorf("get tender by id: %w", err)
+		}
+		// protocol has contents of all bids, so it is only for tender organization
+		return &streamTarget{action: authz.ActionOrganizationView, resource: authz.OrganizationResource(tender.OrganizationID), serviceType: tender.ServiceType}, nil
+
+	case entity.EventBidCreated, entity.EventBidPublished, entity.EventBidWithdrawn:
+		if err := json.Unmarshal(event.Payload, &bid); err != nil {
+			return nil, fmt.Errorf("unmarshal bid: %w", err)
+		}
+
+	case entity.EventBidDecision:
+		var decision entity.BidDecisionEvent
+		if err := json.Unmarshal(event.Payload, &decision); err != nil {
+			return nil, fmt.Errorf("unmarshal decision: %w", err)
+		}
+		bid = decision.Bid
+
+	case entity.EventReviewCreated:
+		var review entity.ReviewCreatedEvent
+		if err := json.Unmarshal(event.Payload, &review); err != nil {
+			return nil, fmt.Errorf("unmarshal review: %w", err)
+		}
+		reviewedBid, err := s.bidRepo.GetBidByID(ctx, review.BidID)
+		if err != nil {
+			return nil, fmt.Errorf("get bid by id: %w", err)
+		}
+		tender, err := s.tenderRepo.GetTenderByID(ctx, reviewedBid.TenderID)
+		if err != nil {
+			return nil, fmt.Errorf("get tender by id: %w", err)
+		}
+		return &streamTarget{action: authz.ActionReviewView, resource: authz.TenderResource(tender), serviceType: tender.ServiceType}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown event type %s", event.Type)
+	}
+
+	// author of anonymous tender bid is hidden in payload, but author still sees own events
+	if bid.AuthorLabel != "" {
+		authored, err := s.bidRepo.GetBidByID(ctx, bid.Id)
+		if err != nil {
+			return nil, fmt.Errorf("get bid by id: %w", err)
+		}
+		bid.AuthorType, bid.AuthorID = authored.AuthorType, authored.AuthorID
+	}
+
+	tender, err := s.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+	return &streamTarget{action: authz.ActionBidView, resource: authz.BidResource(&bid, tender), serviceType: tender.ServiceType}, nil
+}
+
+func (s *EventStream) visible(ctx context.Context, subscriber *streamSubscriber, event *entity.Event, target *streamTarget) bool {
+	if len(subscriber.filter.EventTypes) != 0 && !slices.Contains(subscriber.filter.EventTypes, event.Type) {
+		return false
+	}
+	if len(subscriber.filter.ServiceTypes) != 0 && !slices.Contains(subscriber.filter.ServiceTypes, target.serviceType) {
+		return false
+	}
+
+	ok, err := s.tenderUsecase.authorizer.Can(ctx, subscriber.actor, target.action, target.resource)
+	return err == nil && ok
+}
@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var defaultMessageSort = entity.SortField{Field: "created_at"}
+
+type MessageUsecase struct {
+	messageRepo repos.MessageRepo
+	tenderRepo  repos.TenderRepo
+	bidUsecase  *BidUsecase
+}
+
+func NewMessageUsecase(messageRepo repos.MessageRepo, tenderRepo repos.TenderRepo, bidUsecase *BidUsecase) *MessageUsecase {
+	return &MessageUsecase{
+		messageRepo: messageRepo,
+		tenderRepo:  tenderRepo,
+		bidUsecase:  bidUsecase,
+	}
+}
+
+func (u *MessageUsecase) SendMessage(ctx context.Context, username string, bidID uuid.UUID, text string) (*entity.BidMessage, error) {
+	actor, side, err := u.thread(ctx, username, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := actor.Ref()
+	message, err := u.messageRepo.CreateMessage(ctx, &entity.BidMessage{
+		BidID:     bidID,
+		Side:      side,
+		Text:      text,
+		ActorType: ref.Type,
+		ActorID:   ref.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create message: %w", err)
+	}
+
+	return message, nil
+}
+
+func (u *MessageUsecase) GetMessages(ctx context.Context, username string, bidID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.BidMessage], error) {
+	_, side, err := u.thread(ctx, username, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := u.messageRepo.GetMessagesPage(ctx, []entity.SortField{defaultMessageSort}, *pag, db.WithWhere("bid_id = ?", bidID))
+	if err != nil {
+		return nil, fmt.Errorf("get messages: %w", err)
+	}
+
+	if side == entity.SideTender {
+		if err := u.hideBidder(ctx, bidID, messages.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	return messages, nil
+}
+
+// MarkRead mark messages of other side as read, it is read receipt for them
+func (u *MessageUsecase) MarkRead(ctx context.Context, username string, bidID uuid.UUID) (*entity.MessageCount, error) {
+	_, side, err := u.thread(ctx, username, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.messageRepo.MarkRead(ctx, bidID, side); err != nil {
+		return nil, fmt.Errorf("mark messages read: %w", err)
+	}
+
+	return u.messageRepo.CountUnread(ctx, bidID, side)
+}
+
+func (u *MessageUsecase) GetUnreadCount(ctx context.Context, username string, bidID uuid.UUID) (*entity.MessageCount, error) {
+	_, side, err := u.thread(ctx, username, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := u.messageRepo.CountUnread(ctx, bidID, side)
+	if err != nil {
+		return nil, fmt.Errorf("count unread messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// hideBidder replace authors of bidder side with pseudonym of bid author while evaluation of tender is anonymous
+func (u *MessageUsecase) hideBidder(ctx context.Context, bidID uuid.UUID, messages []entity.BidMessage) error {
+	bid, err := u.bidUsecase.bidRepo.GetBidByID(ctx, bidID)
+	if err != nil {
+		return fmt.Errorf("get bid by id: %w", err)
+	}
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return fmt.Errorf("get tender by id: %w", err)
+	}
+	if !tender.AuthorsHidden() {
+		return nil
+	}
+
+	labels, err := authorLabels(ctx, u.bidUsecase.bidRepo, tender.Id)
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		if messages[i].Side == entity.SideBidder {
+			messages[i].Anonymize(labels[bid.AuthorID])
+		}
+	}
+	return nil
+}
+
+// thread check access to bid conversation and resolve side of actor,
+// responsibles of tender organization talk for tender, everyone else allowed is bidder
+func (u *MessageUsecase) thread(ctx context.Context, username string, bidID uuid.UUID) (*authz.Actor, entity.MessageSide, error) {
+	actor, bid, err := u.bidUsecase.checkBidPermission(ctx, username, bidID, authz.ActionBidMessage, entity.ErrUserPermissionBid)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, "", fmt.Errorf("get tender by id: %w", err)
+	}
+
+	if slices.Contains(actor.OrganizationIDs(), tender.OrganizationID) {
+		return actor, entity.SideTender, nil
+	}
+	return actor, entity.SideBidder, nil
+}
@@ -0,0 +1,371 @@
+package usecases
+
+import (
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/mail"
+	"avito/internal/usecases/repos"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var defaultNotificationSort = entity.SortField{Field: "created_at", Desc: true}
+
+// emailNotificationTypes also sent by email to users with address in profile
+var emailNotificationTypes = []entity.NotificationType{entity.NotifyBidDecision, entity.NotifyVoteRequired}
+
+type voteRequiredData struct {
+	Bid    entity.Bid
+	Tender entity.Tender
+}
+
+type NotificationUsecase struct {
+	notificationRepo repos.NotificationRepo
+	emailRepo        repos.EmailRepo
+	userRepo         repos.UserRepo
+	tenderRepo       repos.TenderRepo
+	bidRepo          repos.BidRepo
+	orgRepo          repos.OrganizationRepo
+	templates        *mail.Templates
+	tenderUsecase    *TenderUsecase
+}
+
+func NewNotificationUsecase(
+	notificationRepo repos.NotificationRepo,
+	emailRepo repos.EmailRepo,
+	userRepo repos.UserRepo,
+	tenderRepo repos.TenderRepo,
+	bidRepo repos.BidRepo,
+	orgRepo repos.OrganizationRepo,
+	templates *mail.Templates,
+	tenderUsecase *TenderUsecase,
+) *NotificationUsecase {
+	return &NotificationUsecase{
+		notificationRepo: notificationRepo,
+		emailRepo:        emailRepo,
+		userRepo:         userRepo,
+		tenderRepo:       tenderRepo,
+		bidRepo:          bidRepo,
+		orgRepo:          orgRepo,
+		templates:        templates,
+		tenderUsecase:    tenderUsecase,
+	}
+}
+
+func (u *NotificationUsecase) GetNotifications(ctx context.Context, username string, unreadOnly bool, pag *entity.Pagination) (*entity.Page[entity.Notification], error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	conds := []db.FilterOption{db.WithWhere("user_id = ?", user.Id)}
+	if unreadOnly {
+		conds = append(conds, db.WithWhere("read_at IS NULL"))
+	}
+
+	notifications, err := u.notificationRepo.GetNotificationsPage(ctx, []entity.SortField{defaultNotificationSort}, *pag, conds...)
+	if err != nil {
+		return nil, fmt.Errorf("get notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (u *NotificationUsecase) GetNotificationCount(ctx context.Context, username string) (*entity.NotificationCount, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	count, err := u.notificationRepo.CountNotifications(ctx, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+func (u *NotificationUsecase) MarkRead(ctx context.Context, username string, notificationID uuid.UUID) (*entity.Notification, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	notification, err := u.notificationRepo.MarkRead(ctx, user.Id, notificationID)
+	if err != nil {
+		return nil, fmt.Errorf("mark read: %w", err)
+	}
+
+	return notification, nil
+}
+
+func (u *NotificationUsecase) MarkAllRead(ctx context.Context, username string) (*entity.NotificationCount, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if _, err := u.notificationRepo.MarkAllRead(ctx, user.Id); err != nil {
+		return nil, fmt.Errorf("mark all read: %w", err)
+	}
+
+	count, err := u.notificationRepo.CountNotifications(ctx, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+func (u *NotificationUsecase) GetPreferences(ctx context.Context, username string) ([]entity.NotificationPreference, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	prefs, err := u.notificationRepo.GetPreferences(ctx, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("get preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (u *NotificationUsecase) SetPreferences(ctx context.Context, username string, prefs []entity.NotificationPreference) ([]entity.NotificationPreference, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if err := u.notificationRepo.SetPreferences(ctx, user.Id, prefs); err != nil {
+		return nil, fmt.Errorf("set preferences: %w", err)
+	}
+
+	prefs, err = u.notificationRepo.GetPreferences(ctx, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("get preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// HandleEvent is event bus subscriber, it creates notifications for users concerned by event
+func (u *NotificationUsecase) HandleEvent(ctx context.Context, event *entity.Event) error {
+	var (
+		notificationType entity.NotificationType
+		recipients       []uuid.UUID
+		data             any
+		err              error
+	)
+
+	switch event.Type {
+	case entity.EventBidDecision:
+		var decision entity.BidDecisionEvent
+		if err := json.Unmarshal(event.Payload, &decision); err != nil {
+			return fmt.Errorf("unmarshal decision: %w", err)
+		}
+		// author of anonymous tender bid is hidden in payload
+		bid, getErr := u.bidRepo.GetBidByID(ctx, decision.Bid.Id)
+		if getErr != nil {
+			return fmt.Errorf("get bid by id: %w", getErr)
+		}
+		notificationType = entity.NotifyBidDecision
+		data = decision
+		recipients, err = u.bidAuthors(ctx, bid)
+
+	case entity.EventReviewCreated:
+		var review entity.ReviewCreatedEvent
+		if err := json.Unmarshal(event.Payload, &review); err != nil {
+			return fmt.Errorf("unmarshal review: %w", err)
+		}
+		bid, getErr := u.bidRepo.GetBidByID(ctx, review.BidID)
+		if getErr != nil {
+			return fmt.Errorf("get bid by id: %w", getErr)
+		}
+		notificationType = entity.NotifyBidReviewed
+		data = review
+		recipients, err = u.bidAuthors(ctx, bid)
+
+	case entity.EventTenderUpdated, entity.EventTenderClosed:
+		var tender entity.Tender
+		if err := json.Unmarshal(event.Payload, &tender); err != nil {
+			return fmt.Errorf("unmarshal tender: %w", err)
+		}
+		notificationType = entity.NotifyTenderUpdated
+		if event.Type == entity.EventTenderClosed {
+			notificationType = entity.NotifyTenderClosed
+		}
+		data = tender
+		recipients, err = u.tenderBidAuthors(ctx, tender.Id)
+
+	case entity.EventBidPublished:
+		var bid entity.Bid
+		if err := json.Unmarshal(event.Payload, &bid); err != nil {
+			return fmt.Errorf("unmarshal bid: %w", err)
+		}
+		tender, getErr := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+		if getErr != nil {
+			return fmt.Errorf("get tender by id: %w", getErr)
+		}
+		// voters are notified about bids of sealed tender when it is opened
+		if tender.BidsSealed() {
+			return nil
+		}
+		notificationType = entity.NotifyVoteRequired
+		data = voteRequiredData{Bid: bid, Tender: *tender}
+		recipients, err = u.tenderUsecase.getVoters(ctx, tender.OrganizationID)
+
+	case entity.EventBidWithdrawn:
+		var bid entity.Bid
+		if err := json.Unmarshal(event.Payload, &bid); err != nil {
+			return fmt.Errorf("unmarshal bid: %w", err)
+		}
+		tender, getErr := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+		if getErr != nil {
+			return fmt.Errorf("get tender by id: %w", getErr)
+		}
+		if tender.BidsSealed() {
+			return nil
+		}
+		notificationType = entity.NotifyBidWithdrawn
+		data = bid
+		recipients, err = u.tenderUsecase.getVoters(ctx, tender.OrganizationID)
+
+	case entity.EventTenderOpened:
+		var protocol entity.OpeningProtocol
+		if err := json.Unmarshal(event.Payload, &protocol); err != nil {
+			return fmt.Errorf("unmarshal protocol: %w", err)
+		}
+		tender, getErr := u.tenderRepo.GetTenderByID(ctx, protocol.TenderID)
+		if getErr != nil {
+			return fmt.Errorf("get tender by id: %w", getErr)
+		}
+		notificationType = entity.NotifyTenderOpened
+		data = protocol
+		recipients, err = u.tenderUsecase.getVoters(ctx, tender.OrganizationID)
+
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get recipients: %w", err)
+	}
+
+	return u.notify(ctx, event, notificationType, recipients, data)
+}
+
+// notify create notifications for recipients who didnt opt out, data is used to render emails
+func (u *NotificationUsecase) notify(ctx context.Context, event *entity.Event, notificationType entity.NotificationType, recipients []uuid.UUID, data any) error {
+	slices.SortFunc(recipients, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+	recipients = slices.Compact(recipients)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	optedOut, err := u.notificationRepo.GetOptedOutUsers(ctx, notificationType, recipients)
+	if err != nil {
+		return fmt.Errorf("get opted out users: %w", err)
+	}
+
+	notifications := []entity.Notification{}
+	userIDs := []uuid.UUID{}
+	for _, userID := range recipients {
+		if slices.Contains(optedOut, userID) {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+		notifications = append(notifications, entity.Notification{
+			UserID:  userID,
+			EventID: event.Id,
+			Type:    notificationType,
+			Data:    event.Payload,
+		})
+	}
+
+	if err := u.notificationRepo.CreateNotifications(ctx, notifications); err != nil {
+		return fmt.Errorf("create notifications: %w", err)
+	}
+
+	if !slices.Contains(emailNotificationTypes, notificationType) || len(userIDs) == 0 {
+		return nil
+	}
+
+	return u.email(ctx, event, notificationType, userIDs, data)
+}
+
+// email queue emails in the same transaction, they are sent by email dispatcher
+func (u *NotificationUsecase) email(ctx context.Context, event *entity.Event, notificationType entity.NotificationType, userIDs []uuid.UUID, data any) error {
+	users, err := u.userRepo.GetUsersByFilter(ctx,
+		db.WithWhere("id IN ?", userIDs),
+		db.WithWhere("active = ?", true),
+		db.WithWhere("email <> ''"),
+	)
+	if err != nil {
+		return fmt.Errorf("get users: %w", err)
+	}
+
+	emails := []entity.Email{}
+	for _, user := range users {
+		subject, body, err := u.templates.Render(user.Locale, string(notificationType), struct {
+			User entity.User
+			Data any
+		}{User: user, Data: data})
+		if err != nil {
+			return fmt.Errorf("render email: %w", err)
+		}
+
+		emails = append(emails, entity.Email{
+			UserID:           user.Id,
+			EventID:          event.Id,
+			NotificationType: notificationType,
+			To:               user.Email,
+			Subject:          subject,
+			Body:             body,
+		})
+	}
+
+	if err := u.emailRepo.CreateEmails(ctx, emails); err != nil {
+		return fmt.Errorf("create emails: %w", err)
+	}
+
+	return nil
+}
+
+// bidAuthors get author of bid or all responsibles of author organization
+func (u *NotificationUsecase) bidAuthors(ctx context.Context, bid *entity.Bid) ([]uuid.UUID, error) {
+	if bid.AuthorType == entity.AuthorUser {
+		return []uuid.UUID{bid.AuthorID}, nil
+	}
+
+	members, err := u.orgRepo.GetOrgMemberships(ctx, bid.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get org members: %w", err)
+	}
+
+	userIDs := []uuid.UUID{}
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+	}
+	return userIDs, nil
+}
+
+func (u *NotificationUsecase) tenderBidAuthors(ctx context.Context, tenderID uuid.UUID) ([]uuid.UUID, error) {
+	bids, err := u.bidRepo.GetBidsByFilter(ctx, db.WithWhere("tender_id = ?", tenderID))
+	if err != nil {
+		return nil, fmt.Errorf("get bids: %w", err)
+	}
+
+	userIDs := []uuid.UUID{}
+	for _, bid := range bids {
+		authors, err := u.bidAuthors(ctx, &bid)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, authors...)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,233 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	"avito/internal/config"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const openingBatchSize = 20
+
+// OpeningUsecase reveal bids of sealed tenders to tender organization all at once,
+// at deadline or earlier by quorum of responsibles
+type OpeningUsecase struct {
+	tenderRepo    repos.TenderRepo
+	bidRepo       repos.BidRepo
+	tenderUsecase *TenderUsecase
+	cfg           config.Tender
+}
+
+func NewOpeningUsecase(tenderRepo repos.TenderRepo, bidRepo repos.BidRepo, tenderUsecase *TenderUsecase, cfg *config.Tender) *OpeningUsecase {
+	return &OpeningUsecase{
+		tenderRepo:    tenderRepo,
+		bidRepo:       bidRepo,
+		tenderUsecase: tenderUsecase,
+		cfg:           *cfg,
+	}
+}
+
+// VoteOpening count vote of responsible, tender is opened when votes reach quorum
+func (u *OpeningUsecase) VoteOpening(ctx context.Context, username string, tenderID uuid.UUID) (*entity.OpeningVotes, error) {
+	actor, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	var votes *entity.OpeningVotes
+	err = u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := u.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if !tender.Sealed {
+			return entity.ErrTenderNotSealed
+		}
+		if tender.OpenedAt != nil {
+			return entity.ErrTenderOpened
+		}
+
+		if _, err := u.tenderRepo.AddOpeningVote(ctx, tenderID, actor.User.Id); err != nil {
+			return fmt.Errorf("add opening vote: %w", err)
+		}
+		count, err := u.tenderRepo.CountOpeningVotes(ctx, tenderID)
+		if err != nil {
+			return fmt.Errorf("count opening votes: %w", err)
+		}
+
+		// quorum is counted like quorum of bid approval
+		openers, err := u.tenderUsecase.getMembersAllowed(ctx, tender.OrganizationID, authz.ActionTenderOpen)
+		if err != nil {
+			return fmt.Errorf("get openers: %w", err)
+		}
+		votes = &entity.OpeningVotes{Votes: count, Quorum: min(3, len(openers))}
+		if count < int64(votes.Quorum) {
+			return nil
+		}
+
+		protocol, err := u.open(ctx, tender, entity.OpenedByQuorum)
+		if err != nil {
+			return err
+		}
+		votes.OpenedAt = &protocol.OpenedAt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// GetOpeningProtocol get protocol of opened tender, it is visible to tender organization
+func (u *OpeningUsecase) GetOpeningProtocol(ctx context.Context, username string, tenderID uuid.UUID) (*entity.OpeningProtocol, error) {
+	if _, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionOrganizationView); err != nil {
+		return nil, err
+	}
+
+	protocol, err := u.tenderRepo.GetOpeningProtocol(ctx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get opening protocol: %w", err)
+	}
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+	if err := u.hideAuthors(ctx, tender, protocol); err != nil {
+		return nil, err
+	}
+
+	return protocol, nil
+}
+
+// Run open sealed tenders with passed deadline until ctx is done
+func (u *OpeningUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.cfg.OpenPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := u.openExpired(ctx); err != nil {
+				log.Printf("open sealed tenders: %v", err)
+			}
+		}
+	}
+}
+
+func (u *OpeningUsecase) openExpired(ctx context.Context) error {
+	tenders, err := u.tenderRepo.GetTendersByFilter(ctx,
+		db.WithWhere("sealed = ?", true),
+		db.WithWhere("opened_at IS NULL"),
+		db.WithWhere("deadline <= ?", time.Now()),
+		db.WithLimit(openingBatchSize),
+	)
+	if err != nil {
+		return fmt.Errorf("get tenders to open: %w", err)
+	}
+
+	for _, tender := range tenders {
+		err := u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			locked, err := u.lockTender(ctx, tender.Id)
+			if err != nil {
+				return err
+			}
+			// opened by quorum or other instance meanwhile
+			if locked.OpenedAt != nil {
+				return nil
+			}
+
+			_, err = u.open(ctx, locked, entity.OpenedByDeadline)
+			return err
+		})
+		if err != nil {
+			log.Printf("open tender %s: %v", tender.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// open reveal published bids and save them to protocol, must be called within transaction holding tender lock
+func (u *OpeningUsecase) open(ctx context.Context, tender *entity.Tender, reason entity.OpeningReason) (*entity.OpeningProtocol, error) {
+	bids, err := u.bidRepo.GetBidsByFilter(ctx,
+		db.WithWhere("tender_id = ?", tender.Id),
+		db.WithWhere("status = ?", entity.BPublished),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get published bids: %w", err)
+	}
+
+	protocol := &entity.OpeningProtocol{
+		TenderID: tender.Id,
+		Reason:   reason,
+		OpenedAt: time.Now(),
+		Bids:     []entity.OpenedBid{},
+	}
+	for _, bid := range bids {
+		protocol.Bids = append(protocol.Bids, entity.OpenedBid{
+			BidID:       bid.Id,
+			Name:        bid.Name,
+			Description: bid.Description,
+			AuthorType:  bid.AuthorType,
+			AuthorID:    bid.AuthorID,
+			Version:     bid.Version,
+		})
+	}
+
+	protocol, err = u.tenderRepo.OpenTender(ctx, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("open tender: %w", err)
+	}
+
+	// stored protocol keeps authors, event goes to tender organization
+	payload := *protocol
+	payload.Bids = slices.Clone(protocol.Bids)
+	if err := u.hideAuthors(ctx, tender, &payload); err != nil {
+		return nil, err
+	}
+	if err := u.tenderUsecase.publishEvent(ctx, entity.EventTenderOpened, payload, tender.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	return protocol, nil
+}
+
+// hideAuthors replace authors in protocol with pseudonyms while evaluation of tender is anonymous
+func (u *OpeningUsecase) hideAuthors(ctx context.Context, tender *entity.Tender, protocol *entity.OpeningProtocol) error {
+	if !tender.AuthorsHidden() {
+		return nil
+	}
+
+	labels, err := authorLabels(ctx, u.bidRepo, tender.Id)
+	if err != nil {
+		return err
+	}
+	for i := range protocol.Bids {
+		protocol.Bids[i].Anonymize(labels[protocol.Bids[i].AuthorID])
+	}
+	return nil
+}
+
+func (u *OpeningUsecase) lockTender(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error) {
+	tenders, err := u.tenderRepo.GetTendersByFilter(ctx, db.WithWhere("id = ?", tenderID), db.WithLockForUpdate())
+	if err != nil {
+		return nil, fmt.Errorf("lock tender: %w", err)
+	}
+	if len(tenders) == 0 {
+		return nil, entity.ErrTenderNotFound
+	}
+
+	return &tenders[0], nil
+}
@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var (
+	defaultOrganizationSort = entity.SortField{Field: "name"}
+	defaultInvitationSort   = entity.SortField{Field: "created_at", Desc: true}
+	defaultAuditSort        = entity.SortField{Field: "created_at", Desc: true}
+)
+
+type OrganizationUsecase struct {
+	orgRepo       repos.OrganizationRepo
+	tenderUsecase *TenderUsecase
+}
+
+func NewOrganizationUsecase(orgRepo repos.OrganizationRepo, tenderUsecase *TenderUsecase) *OrganizationUsecase {
+	return &OrganizationUsecase{
+		orgRepo:       orgRepo,
+		tenderUsecase: tenderUsecase,
+	}
+}
+
+func (u *OrganizationUsecase) CreateOrganization(ctx context.Context, username string, org *entity.Organization) (*entity.Organization, error) {
+	user, _, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	org, err = u.orgRepo.CreateOrganization(ctx, org, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+
+	return org, nil
+}
+
+func (u *OrganizationUsecase) GetOrganization(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error) {
+	org, err := u.orgRepo.GetOrgByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get org by id: %w", err)
+	}
+
+	return org, nil
+}
+
+func (u *OrganizationUsecase) GetOrganizations(ctx context.Context, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Organization], error) {
+	orgs, err := u.orgRepo.GetOrgsPage(ctx, query.SortOr(defaultOrganizationSort), *pag,
+		db.WithFilters(query.Filters),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+func (u *OrganizationUsecase) GetMyOrganizations(ctx context.Context, username string, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Organization], error) {
+	_, userOrgsIDs, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user orgs ids: %w", err)
+	}
+
+	orgs, err := u.orgRepo.GetOrgsPage(ctx, query.SortOr(defaultOrganizationSort), *pag,
+		db.WithWhere("id IN ?", userOrgsIDs),
+		db.WithFilters(query.Filters),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+func (u *OrganizationUsecase) PatchOrganization(ctx context.Context, username string, orgID uuid.UUID, patchOrg *entity.Organization) (*entity.Organization, error) {
+	actor, err := u.checkUserResponsibleOrg(ctx, username, authz.ActionOrganizationManage, authz.OrganizationResource(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := u.orgRepo.PatchOrganization(ctx, orgID, patchOrg, actor.User.Id)
+	if err != nil {
+		return nil, fmt.Errorf("patch organization: %w", err)
+	}
+
+	return org, nil
+}
+
+func (u *OrganizationUsecase) GetResponsibles(ctx context.Context, username string, orgID uuid.UUID) ([]entity.OrganizationMember, error) {
+	if _, err := u.checkUserResponsibleOrg(ctx, username, authz.ActionOrganizationView, authz.OrganizationResource(orgID)); err != nil {
+		return nil, err
+	}
+
+	members, err := u.orgRepo.GetOrgResponsibles(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get org responsibles: %w", err)
+	}
+
+	return members, nil
+}
+
+// RemoveResponsible remove other responsible or leave organization if user removes himself
+func (u *OrganizationUsecase) RemoveResponsible(ctx context.Context, username string, orgID uuid.UUID, userID uuid.UUID) error {
+	actor, err := u.checkUserResponsibleOrg(ctx, username, authz.ActionResponsibleRemove, authz.MemberResource(orgID, userID))
+	if err != nil {
+		return err
+	}
+
+	if err := u.orgRepo.RemoveResponsible(ctx, orgID, userID, actor.User.Id); err != nil {
+		return fmt.Errorf("remove responsible: %w", err)
+	}
+
+	return nil
+}
+
+func (u *OrganizationUsecase) SetResponsibleRole(ctx context.Context, username string, orgID uuid.UUID, userID uuid.UUID, role entity.OrganizationRole) (*entity.Membership, error) {
+	actor, err := u.checkUserResponsibleOrg(ctx, username, authz.ActionOrganizationManage, authz.OrganizationResource(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := u.orgRepo.SetResponsibleRole(ctx, orgID, userID, role, actor.User.Id)
+	if err != nil {
+		return nil, fmt.Errorf("set responsible role: %w", err)
+	}
+
+	return membership, nil
+}
+
+func (u *OrganizationUsecase) InviteUser(ctx context.Context, username string, orgID uuid.UUID, inviteeUsername string, role entity.OrganizationRole) (*entity.OrganizationInvitation, error) {
+	actor, err := u.checkUserResponsibleOrg(ctx, username, authz.ActionOrganizationManage, authz.OrganizationResource(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	invitee, inviteeOrgsIDs, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, inviteeUsername)
+	if err != nil {
+		return nil, fmt.Errorf("get invitee: %w", err)
+	}
+	if slices.Contains(inviteeOrgsIDs, orgID) {
+		return nil, entity.ErrAlreadyResponsible
+	}
+
+	pending, err := u.orgRepo.GetInvitationsPage(ctx, []entity.SortField{defaultInvitationSort}, entity.Pagination{Limit: 1},
+		db.WithWhere("organization_id = ?", orgID),
+		db.WithWhere("user_id = ?", invitee.Id),
+		db.WithWhere("status = ?", entity.InvitationPending),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get pending invitations: %w", err)
+	}
+	if len(pending.Items) != 0 {
+		return nil, entity.ErrInvitationExists
+	}
+
+	invitation, err := u.orgRepo.CreateInvitation(ctx, &entity.OrganizationInvitation{
+		OrganizationID: orgID,
+		UserID:         invitee.Id,
+		InviterID:      actor.User.Id,
+		Role:           role,
+		Status:         entity.InvitationPending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (u *OrganizationUsecase) GetOrgInvitations(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.OrganizationInvitation], error) {
+	if _, err := u.checkUserResponsibleOrg(ctx, username, authz.ActionOrganizationManage, authz.OrganizationResource(orgID)); err != nil {
+		return nil, err
+	}
+
+	invitations, err := u.orgRepo.GetInvitationsPage(ctx, []entity.SortField{defaultInvitationSort}, *pag,
+		db.WithWhere("organization_id = ?", orgID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (u *OrganizationUsecase) GetMyInvitations(ctx context.Context, username string, pag *entity.Pagination) (*entity.Page[entity.OrganizationInvitation], error) {
+	user, _, err := u.tenderUsecase.getUserAndUserOrgsIDs(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	invitations, err := u.orgRepo.GetInvitationsPage(ctx, []entity.SortField{defaultInvitationSort}, *pag,
+		db.WithWhere("user_id = ?", user.Id),
+		db.WithWhere("status = ?", entity.InvitationPending),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// ResolveInvitation accept or decline invitation by invitee, or revoke by organization manager
+func (u *OrganizationUsecase) ResolveInvitation(ctx context.Context, username string, invitationID uuid.UUID, status entity.InvitationStatusType) (*entity.OrganizationInvitation, error) {
+	invitation, err := u.orgRepo.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("get invitation by id: %w", err)
+	}
+
+	actor, err := u.tenderUsecase.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	action := authz.ActionInvitationRespond
+	if status == entity.InvitationRevoked {
+		action = authz.ActionOrganizationManage
+	}
+	if err := u.tenderUsecase.authorize(ctx, actor, action, authz.InvitationResource(invitation), entity.ErrUserPermissionInvitation); err != nil {
+		return nil, err
+	}
+
+	if invitation.Status != entity.InvitationPending {
+		return nil, entity.ErrInvitationNotPending
+	}
+
+	invitation, err = u.orgRepo.ResolveInvitation(ctx, invitationID, status, actor.User.Id)
+	if err != nil {
+		return nil, fmt.Errorf("resolve invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (u *OrganizationUsecase) GetAudit(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.OrganizationAudit], error) {
+	if _, err := u.checkUserResponsibleOrg(ctx, username, authz.ActionOrganizationView, authz.OrganizationResource(orgID)); err != nil {
+		return nil, err
+	}
+
+	audit, err := u.orgRepo.GetAuditPage(ctx, []entity.SortField{defaultAuditSort}, *pag,
+		db.WithWhere("organization_id = ?", orgID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get audit: %w", err)
+	}
+
+	return audit, nil
+}
+
+func (u *OrganizationUsecase) checkUserResponsibleOrg(ctx context.Context, username string, action authz.Action, resource authz.Resource) (*authz.Actor, error) {
+	if _, err := u.orgRepo.GetOrgByID(ctx, resource.OrganizationID); err != nil {
+		return nil, fmt.Errorf("get org by id: %w", err)
+	}
+
+	actor, err := u.tenderUsecase.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	if err := u.tenderUsecase.authorize(ctx, actor, action, resource, entity.ErrUserPermissionOrg); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
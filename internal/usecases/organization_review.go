@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var defaultOrgReviewSort = entity.SortField{Field: "created_at", Desc: true}
+
+// OrgReviewUsecase is reviews of tender organizations by bid authors, reviews and rating are public
+type OrgReviewUsecase struct {
+	orgRepo    repos.OrganizationRepo
+	tenderRepo repos.TenderRepo
+	bidUsecase *BidUsecase
+}
+
+func NewOrgReviewUsecase(orgRepo repos.OrganizationRepo, tenderRepo repos.TenderRepo, bidUsecase *BidUsecase) *OrgReviewUsecase {
+	return &OrgReviewUsecase{
+		orgRepo:    orgRepo,
+		tenderRepo: tenderRepo,
+		bidUsecase: bidUsecase,
+	}
+}
+
+// ReviewOrganization review tender organization once per bid, after bid is approved or rejected
+func (u *OrgReviewUsecase) ReviewOrganization(ctx context.Context, username string, bidID uuid.UUID, review *entity.OrganizationReview) (*entity.OrganizationReview, error) {
+	actor, bid, err := u.bidUsecase.checkBidPermission(ctx, username, bidID, authz.ActionBidEdit, entity.ErrUserPermissionBid)
+	if err != nil {
+		return nil, err
+	}
+
+	if bid.Decision == "" {
+		return nil, entity.ErrBidNotDecided
+	}
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+
+	existing, err := u.orgRepo.GetOrgReviewsPage(ctx, []entity.SortField{defaultOrgReviewSort}, entity.Pagination{Limit: 1},
+		db.WithWhere("bid_id = ?", bidID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get organization reviews: %w", err)
+	}
+	if len(existing.Items) != 0 {
+		return nil, entity.ErrOrgReviewExists
+	}
+
+	ref := actor.Ref()
+	review.OrganizationID = tender.OrganizationID
+	review.BidID = bidID
+	review.ActorType = ref.Type
+	review.ActorID = ref.ID
+
+	created, err := u.orgRepo.CreateOrgReview(ctx, review)
+	if err != nil {
+		return nil, fmt.Errorf("create organization review: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *OrgReviewUsecase) GetOrganizationReviews(ctx context.Context, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.OrganizationReview], error) {
+	if _, err := u.orgRepo.GetOrgByID(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("get org by id: %w", err)
+	}
+
+	reviews, err := u.orgRepo.GetOrgReviewsPage(ctx, []entity.SortField{defaultOrgReviewSort}, *pag,
+		db.WithWhere("organization_id = ?", orgID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get organization reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (u *OrgReviewUsecase) GetOrganizationRating(ctx context.Context, orgID uuid.UUID) (*entity.OrganizationRating, error) {
+	if _, err := u.orgRepo.GetOrgByID(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("get org by id: %w", err)
+	}
+
+	ratings, err := u.orgRepo.GetOrgRatings(ctx, uuid.UUIDs{orgID})
+	if err != nil {
+		return nil, fmt.Errorf("get organization rating: %w", err)
+	}
+
+	rating := ratings[orgID]
+	return &rating, nil
+}
@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	"avito/internal/config"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var defaultQuestionSort = entity.SortField{Field: "created_at"}
+
+type QuestionUsecase struct {
+	questionRepo  repos.QuestionRepo
+	tenderRepo    repos.TenderRepo
+	tenderUsecase *TenderUsecase
+	cfg           config.Tender
+}
+
+func NewQuestionUsecase(questionRepo repos.QuestionRepo, tenderRepo repos.TenderRepo, tenderUsecase *TenderUsecase, cfg *config.Tender) *QuestionUsecase {
+	return &QuestionUsecase{
+		questionRepo:  questionRepo,
+		tenderRepo:    tenderRepo,
+		tenderUsecase: tenderUsecase,
+		cfg:           *cfg,
+	}
+}
+
+// AskQuestion any user can ask about published tender until question cutoff
+func (u *QuestionUsecase) AskQuestion(ctx context.Context, username string, tenderID uuid.UUID, question string) (*entity.TenderQuestion, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+
+	if tender.Status != entity.Published {
+		return nil, entity.ErrQuestionTender
+	}
+	if tender.Deadline != nil && !time.Now().Before(tender.Deadline.Add(-u.cfg.QuestionCutoff)) {
+		return nil, entity.ErrQuestionsClosed
+	}
+
+	created, err := u.questionRepo.CreateQuestion(ctx, &entity.TenderQuestion{
+		TenderID: tenderID,
+		AuthorID: user.Id,
+		Question: question,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create question: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetQuestions get answered questions, they are visible to everyone who can see tender
+func (u *QuestionUsecase) GetQuestions(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.TenderQuestion], error) {
+	if _, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderView); err != nil {
+		return nil, err
+	}
+
+	return u.getQuestions(ctx, pag,
+		db.WithWhere("tender_id = ?", tenderID),
+		db.WithWhere("answer IS NOT NULL"),
+	)
+}
+
+// GetPendingQuestions get unanswered questions for responsibles who can answer them
+func (u *QuestionUsecase) GetPendingQuestions(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.TenderQuestion], error) {
+	if _, err := u.tenderUsecase.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderEdit); err != nil {
+		return nil, err
+	}
+
+	return u.getQuestions(ctx, pag,
+		db.WithWhere("tender_id = ?", tenderID),
+		db.WithWhere("answer IS NULL"),
+	)
+}
+
+// GetMyQuestions get questions of user to tender including unanswered
+func (u *QuestionUsecase) GetMyQuestions(ctx context.Context, username string, tenderID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.TenderQuestion], error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if _, err := u.tenderRepo.GetTenderByID(ctx, tenderID); err != nil {
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+
+	return u.getQuestions(ctx, pag,
+		db.WithWhere("tender_id = ?", tenderID),
+		db.WithWhere("author_id = ?", user.Id),
+	)
+}
+
+// AnswerQuestion answer or correct answer, answered question is published
+func (u *QuestionUsecase) AnswerQuestion(ctx context.Context, username string, questionID uuid.UUID, answer string) (*entity.TenderQuestion, error) {
+	question, err := u.questionRepo.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("get question by id: %w", err)
+	}
+
+	actor, err := u.tenderUsecase.checkPermissionForTender(ctx, username, question.TenderID, authz.ActionTenderEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err = u.questionRepo.AnswerQuestion(ctx, questionID, answer, actor.Ref().ID)
+	if err != nil {
+		return nil, fmt.Errorf("answer question: %w", err)
+	}
+
+	return question, nil
+}
+
+func (u *QuestionUsecase) getQuestions(ctx context.Context, pag *entity.Pagination, filters ...db.FilterOption) (*entity.Page[entity.TenderQuestion], error) {
+	questions, err := u.questionRepo.GetQuestionsPage(ctx, []entity.SortField{defaultQuestionSort}, *pag, filters...)
+	if err != nil {
+		return nil, fmt.Errorf("get questions: %w", err)
+	}
+
+	return questions, nil
+}
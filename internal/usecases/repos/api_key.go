@@ -0,0 +1,18 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ApiKeyRepo interface {
+	CreateApiKey(ctx context.Context, key *entity.ApiKey) (*entity.ApiKey, error)
+	GetApiKeyByID(ctx context.Context, id uuid.UUID) (*entity.ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (*entity.ApiKey, error)
+	GetApiKeysPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.ApiKey], error)
+	RevokeApiKey(ctx context.Context, id uuid.UUID, actorID uuid.UUID) (*entity.ApiKey, error)
+	TouchApiKey(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,23 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentRepo interface {
+	CreateAttachment(ctx context.Context, attachment *entity.Attachment) (*entity.Attachment, error)
+	GetAttachmentByID(ctx context.Context, id uuid.UUID) (*entity.Attachment, error)
+	GetAttachmentsByFilter(ctx context.Context, filters ...repos.FilterOption) ([]entity.Attachment, error)
+}
+
+// BlobStore keeps attachment contents by key
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -4,6 +4,7 @@ import (
 	"avito/internal/db/repos"
 	"avito/internal/entity"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,14 +13,26 @@ import (
 type BidRepo interface {
 	CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bid, error)
 	GetBidsByFilter(ctx context.Context, filters ...repos.FilterOption) ([]entity.Bid, error)
+	GetBidsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.Bid], error)
+	StreamBids(ctx context.Context, sort []entity.SortField, fn func(*entity.Bid) error, filters ...repos.FilterOption) error
 	GetBidByID(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error)
 	UpdateBidStatus(ctx context.Context, bidID uuid.UUID, newStatus entity.BidStatusType) error
+	SetBidDecision(ctx context.Context, bidID uuid.UUID, decision entity.BidDecisionType) error
+	TransitBid(ctx context.Context, bidID uuid.UUID, fields map[string]any, actor entity.ActorRef) (*entity.Bid, error)
+	GetBidVersionsPage(ctx context.Context, bidID uuid.UUID, sort []entity.SortField, pag entity.Pagination) (*entity.Page[entity.Bid], error)
 	PatchBid(ctx context.Context, bidID uuid.UUID, patchBid *entity.Bid) (*entity.Bid, error)
 	CreateFeedback(ctx context.Context, feedback *entity.BidRewiew) (*entity.BidRewiew, error)
 	ShipBid(ctx context.Context, userID uuid.UUID, bidID uuid.UUID) (bool, error)
 	UnshipsBid(ctx context.Context, bidID uuid.UUID) error
-	RollbackBid(ctx context.Context, bidID uuid.UUID, version int) (*entity.Bid, error)
+	RollbackBid(ctx context.Context, bidID uuid.UUID, version int, actor entity.ActorRef) (*entity.Bid, error)
+	GetFeedbackByID(ctx context.Context, id uuid.UUID) (*entity.BidRewiew, error)
+	UpdateFeedback(ctx context.Context, id uuid.UUID, fields map[string]any) (*entity.BidRewiew, error)
+	CreateFeedbackVersion(ctx context.Context, version *entity.ReviewVersion) error
+	GetFeedbackVersions(ctx context.Context, reviewID uuid.UUID) ([]entity.ReviewVersion, error)
 	GetFeedbacksByFilter(ctx context.Context, filters ...repos.FilterOption) ([]entity.BidRewiew, error)
+	GetReputation(ctx context.Context, authorType entity.BidAuthorType, authorID uuid.UUID, recentSince time.Time) (*entity.Reputation, error)
+	GetFeedbacksPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.BidRewiew], error)
+	StreamFeedbacks(ctx context.Context, sort []entity.SortField, fn func(*entity.BidRewiew) error, filters ...repos.FilterOption) error
 
 	GetClear() *gorm.DB
 }
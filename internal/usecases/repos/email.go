@@ -0,0 +1,13 @@
+package repos
+
+import (
+	"avito/internal/entity"
+	"context"
+	"time"
+)
+
+type EmailRepo interface {
+	CreateEmails(ctx context.Context, emails []entity.Email) error
+	ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]entity.Email, error)
+	SaveEmailAttempt(ctx context.Context, email *entity.Email) error
+}
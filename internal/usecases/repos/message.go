@@ -0,0 +1,16 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type MessageRepo interface {
+	CreateMessage(ctx context.Context, message *entity.BidMessage) (*entity.BidMessage, error)
+	GetMessagesPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.BidMessage], error)
+	MarkRead(ctx context.Context, bidID uuid.UUID, reader entity.MessageSide) (int64, error)
+	CountUnread(ctx context.Context, bidID uuid.UUID, reader entity.MessageSide) (*entity.MessageCount, error)
+}
@@ -0,0 +1,21 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepo interface {
+	CreateNotifications(ctx context.Context, notifications []entity.Notification) error
+	GetNotificationsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.Notification], error)
+	CountNotifications(ctx context.Context, userID uuid.UUID) (*entity.NotificationCount, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID) (*entity.Notification, error)
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]entity.NotificationPreference, error)
+	SetPreferences(ctx context.Context, userID uuid.UUID, prefs []entity.NotificationPreference) error
+	GetOptedOutUsers(ctx context.Context, notificationType entity.NotificationType, userIDs []uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,34 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationRepo interface {
+	CreateOrganization(ctx context.Context, org *entity.Organization, creatorID uuid.UUID) (*entity.Organization, error)
+	GetOrgByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+	GetOrgsByFilter(ctx context.Context, filters ...repos.FilterOption) ([]entity.Organization, error)
+	GetOrgsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.Organization], error)
+	PatchOrganization(ctx context.Context, orgID uuid.UUID, patchOrg *entity.Organization, actorID uuid.UUID) (*entity.Organization, error)
+	GetOrgMemberships(ctx context.Context, orgID uuid.UUID) ([]entity.Membership, error)
+	GetUserMemberships(ctx context.Context, userID uuid.UUID) ([]entity.Membership, error)
+	GetUserOrgsUUIDs(ctx context.Context, userID uuid.UUID) (uuid.UUIDs, error)
+	GetOrgResponsibles(ctx context.Context, orgID uuid.UUID) ([]entity.OrganizationMember, error)
+	RemoveResponsible(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, actorID uuid.UUID) error
+	SetResponsibleRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role entity.OrganizationRole, actorID uuid.UUID) (*entity.Membership, error)
+
+	CreateInvitation(ctx context.Context, invitation *entity.OrganizationInvitation) (*entity.OrganizationInvitation, error)
+	GetInvitationByID(ctx context.Context, invitationID uuid.UUID) (*entity.OrganizationInvitation, error)
+	GetInvitationsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.OrganizationInvitation], error)
+	ResolveInvitation(ctx context.Context, invitationID uuid.UUID, status entity.InvitationStatusType, actorID uuid.UUID) (*entity.OrganizationInvitation, error)
+
+	GetAuditPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.OrganizationAudit], error)
+
+	CreateOrgReview(ctx context.Context, review *entity.OrganizationReview) (*entity.OrganizationReview, error)
+	GetOrgReviewsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.OrganizationReview], error)
+	GetOrgRatings(ctx context.Context, orgIDs uuid.UUIDs) (map[uuid.UUID]entity.OrganizationRating, error)
+}
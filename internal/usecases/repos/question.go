@@ -0,0 +1,16 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type QuestionRepo interface {
+	CreateQuestion(ctx context.Context, question *entity.TenderQuestion) (*entity.TenderQuestion, error)
+	GetQuestionByID(ctx context.Context, id uuid.UUID) (*entity.TenderQuestion, error)
+	GetQuestionsPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.TenderQuestion], error)
+	AnswerQuestion(ctx context.Context, id uuid.UUID, answer string, answererID uuid.UUID) (*entity.TenderQuestion, error)
+}
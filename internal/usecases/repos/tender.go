@@ -10,14 +10,15 @@ import (
 
 type TenderRepo interface {
 	CreateTender(ctx context.Context, tender *entity.Tender) (*entity.Tender, error)
-	GetUserByUserName(ctx context.Context, username string) (*entity.User, error)
-	GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
-	GetOrgByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
 	GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error)
 	GetTendersByFilter(ctx context.Context, filters ...repos.FilterOption) ([]entity.Tender, error)
-	GetUserOrgsUUIDs(ctx context.Context, userID uuid.UUID) (uuid.UUIDs, error)
-	GetOrgUsersIDsByID(ctx context.Context, id uuid.UUID) (uuid.UUIDs, error)
+	GetTendersPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.Tender], error)
+	StreamTenders(ctx context.Context, sort []entity.SortField, fn func(*entity.Tender) error, filters ...repos.FilterOption) error
 	UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, newStatus entity.TenderStatusType) error
 	PatchTender(ctx context.Context, tenderID uuid.UUID, patchTender *entity.Tender) (*entity.Tender, error)
-	RollbackTender(ctx context.Context, tenderID uuid.UUID, version int) (*entity.Tender, error)
+	RollbackTender(ctx context.Context, tenderID uuid.UUID, version int, actor entity.ActorRef) (*entity.Tender, error)
+	AddOpeningVote(ctx context.Context, tenderID uuid.UUID, userID uuid.UUID) (bool, error)
+	CountOpeningVotes(ctx context.Context, tenderID uuid.UUID) (int64, error)
+	OpenTender(ctx context.Context, protocol *entity.OpeningProtocol) (*entity.OpeningProtocol, error)
+	GetOpeningProtocol(ctx context.Context, tenderID uuid.UUID) (*entity.OpeningProtocol, error)
 }
@@ -0,0 +1,18 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserRepo interface {
+	CreateUser(ctx context.Context, user *entity.User) (*entity.User, error)
+	GetUserByUserName(ctx context.Context, username string) (*entity.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	GetUsersByFilter(ctx context.Context, filters ...repos.FilterOption) ([]entity.User, error)
+	PatchUser(ctx context.Context, userID uuid.UUID, patchUser *entity.User) (*entity.User, error)
+	DeactivateUser(ctx context.Context, userID uuid.UUID) error
+}
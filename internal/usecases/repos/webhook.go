@@ -0,0 +1,43 @@
+package repos
+
+import (
+	"avito/internal/db/repos"
+	"avito/internal/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type OutboxRepo interface {
+	AddEvent(ctx context.Context, event *entity.Event) (*entity.Event, error)
+	GetEventsAfter(ctx context.Context, seq int64, limit int) ([]entity.Event, error)
+	GetLastEventSeq(ctx context.Context) (int64, error)
+	ClaimEvents(ctx context.Context, limit int, maxAttempts int, lease time.Duration) ([]entity.Event, error)
+	MarkEventHandled(ctx context.Context, eventID uuid.UUID, handler string) (bool, error)
+	MarkEventProcessed(ctx context.Context, eventID uuid.UUID) error
+	FailEvent(ctx context.Context, eventID uuid.UUID, errText string, retryAt time.Time) error
+}
+
+type EventListener interface {
+	Listen(ctx context.Context, notify func())
+}
+
+type WebhookRepo interface {
+	CreateWebhook(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error)
+	GetWebhooksPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.Webhook], error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID, actorID uuid.UUID) error
+
+	GetDeliveryByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+	GetDeliveriesPage(ctx context.Context, sort []entity.SortField, pag entity.Pagination, filters ...repos.FilterOption) (*entity.Page[entity.WebhookDelivery], error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) (*entity.WebhookDelivery, error)
+
+	CreateDeliveries(ctx context.Context, event *entity.Event) error
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.DeliveryTask, error)
+	SaveDeliveryAttempt(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
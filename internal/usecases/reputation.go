@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"avito/internal/config"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReputationUsecase summarize reviews of bid authors, reputation is public like profiles
+type ReputationUsecase struct {
+	bidRepo       repos.BidRepo
+	orgRepo       repos.OrganizationRepo
+	tenderUsecase *TenderUsecase
+	cfg           config.Reputation
+}
+
+func NewReputationUsecase(bidRepo repos.BidRepo, orgRepo repos.OrganizationRepo, tenderUsecase *TenderUsecase, cfg *config.Reputation) *ReputationUsecase {
+	return &ReputationUsecase{
+		bidRepo:       bidRepo,
+		orgRepo:       orgRepo,
+		tenderUsecase: tenderUsecase,
+		cfg:           *cfg,
+	}
+}
+
+func (u *ReputationUsecase) GetUserReputation(ctx context.Context, username string) (*entity.Reputation, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	return u.reputation(ctx, entity.AuthorUser, user.Id)
+}
+
+func (u *ReputationUsecase) GetOrganizationReputation(ctx context.Context, orgID uuid.UUID) (*entity.Reputation, error) {
+	if _, err := u.orgRepo.GetOrgByID(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("get org by id: %w", err)
+	}
+
+	return u.reputation(ctx, entity.AuthorOrganization, orgID)
+}
+
+func (u *ReputationUsecase) reputation(ctx context.Context, authorType entity.BidAuthorType, authorID uuid.UUID) (*entity.Reputation, error) {
+	reputation, err := u.bidRepo.GetReputation(ctx, authorType, authorID, time.Now().Add(-u.cfg.TrendWindow))
+	if err != nil {
+		return nil, fmt.Errorf("get reputation: %w", err)
+	}
+
+	return reputation, nil
+}
@@ -0,0 +1,303 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	"avito/internal/config"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var defaultModerationSort = entity.SortField{Field: "flagged_at"}
+
+// ReviewUsecase is review lifecycle after creation: edits by reviewer,
+// reply and flag by bid author and moderation of flagged reviews
+type ReviewUsecase struct {
+	bidRepo       repos.BidRepo
+	bidUsecase    *BidUsecase
+	tenderUsecase *TenderUsecase
+	cfg           config.Review
+}
+
+func NewReviewUsecase(bidRepo repos.BidRepo, bidUsecase *BidUsecase, tenderUsecase *TenderUsecase, cfg *config.Review) *ReviewUsecase {
+	return &ReviewUsecase{
+		bidRepo:       bidRepo,
+		bidUsecase:    bidUsecase,
+		tenderUsecase: tenderUsecase,
+		cfg:           *cfg,
+	}
+}
+
+// EditReview change text or scores of own review within edit window, previous version is kept in history
+func (u *ReviewUsecase) EditReview(ctx context.Context, username string, reviewID uuid.UUID, description string, rating entity.ReviewRating) (*entity.BidRewiew, error) {
+	var edited *entity.BidRewiew
+	err := u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		review, err := u.lockOwnReview(ctx, username, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if review.ModerationStatus == entity.ReviewRemoved {
+			return entity.ErrReviewNotFound
+		}
+		if time.Since(review.CreatedAt) > u.cfg.EditWindow {
+			return entity.ErrReviewEditWindow
+		}
+
+		editedAt := review.CreatedAt
+		if review.UpdatedAt != nil {
+			editedAt = *review.UpdatedAt
+		}
+		if err := u.bidRepo.CreateFeedbackVersion(ctx, &entity.ReviewVersion{
+			ReviewID:      review.Id,
+			Description:   review.Description,
+			Quality:       review.Quality,
+			Timeliness:    review.Timeliness,
+			Communication: review.Communication,
+			EditedAt:      editedAt,
+		}); err != nil {
+			return fmt.Errorf("create review version: %w", err)
+		}
+
+		fields := map[string]any{"updated_at": time.Now()}
+		if description != "" {
+			fields["description"] = description
+		}
+		if rating.Quality != nil {
+			fields["quality"] = *rating.Quality
+		}
+		if rating.Timeliness != nil {
+			fields["timeliness"] = *rating.Timeliness
+		}
+		if rating.Communication != nil {
+			fields["communication"] = *rating.Communication
+		}
+
+		edited, err = u.bidRepo.UpdateFeedback(ctx, reviewID, fields)
+		if err != nil {
+			return fmt.Errorf("update review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return edited, nil
+}
+
+// DeleteReview soft delete own review, it is hidden everywhere and excluded from reputation
+func (u *ReviewUsecase) DeleteReview(ctx context.Context, username string, reviewID uuid.UUID) error {
+	return u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := u.lockOwnReview(ctx, username, reviewID); err != nil {
+			return err
+		}
+
+		if _, err := u.bidRepo.UpdateFeedback(ctx, reviewID, map[string]any{"deleted_at": time.Now()}); err != nil {
+			return fmt.Errorf("delete review: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetReviewHistory get previous versions of review for those who see reviewed bid
+func (u *ReviewUsecase) GetReviewHistory(ctx context.Context, username string, reviewID uuid.UUID) ([]entity.ReviewVersion, error) {
+	review, err := u.bidRepo.GetFeedbackByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("get review by id: %w", err)
+	}
+
+	if _, _, err := u.bidUsecase.checkBidPermission(ctx, username, review.BidID, authz.ActionBidView, entity.ErrUserPermissionReview); err != nil {
+		return nil, err
+	}
+
+	versions, err := u.bidRepo.GetFeedbackVersions(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("get review versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// ReplyReview add single public reply of bid author
+func (u *ReviewUsecase) ReplyReview(ctx context.Context, username string, reviewID uuid.UUID, reply string) (*entity.BidRewiew, error) {
+	var replied *entity.BidRewiew
+	err := u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		review, err := u.lockBidAuthorReview(ctx, username, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if review.Reply != nil {
+			return entity.ErrReviewReplied
+		}
+
+		replied, err = u.bidRepo.UpdateFeedback(ctx, reviewID, map[string]any{
+			"reply":      reply,
+			"replied_at": time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("reply review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return replied, nil
+}
+
+// FlagReview send review of bid author to moderation, it is hidden until resolved
+func (u *ReviewUsecase) FlagReview(ctx context.Context, username string, reviewID uuid.UUID, reason string) (*entity.BidRewiew, error) {
+	var flagged *entity.BidRewiew
+	err := u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		review, err := u.lockBidAuthorReview(ctx, username, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if review.ModerationStatus != entity.ReviewVisible || review.ModeratedAt != nil {
+			return entity.ErrReviewFlagged
+		}
+
+		flagged, err = u.bidRepo.UpdateFeedback(ctx, reviewID, map[string]any{
+			"moderation_status": entity.ReviewFlagged,
+			"flag_reason":       reason,
+			"flagged_at":        time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("flag review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return flagged, nil
+}
+
+// GetModerationQueue get flagged reviews oldest first, only for moderators
+func (u *ReviewUsecase) GetModerationQueue(ctx context.Context, username string, pag *entity.Pagination) (*entity.Page[entity.BidRewiew], error) {
+	if err := u.checkModerator(ctx, username); err != nil {
+		return nil, err
+	}
+
+	reviews, err := u.bidRepo.GetFeedbacksPage(ctx, []entity.SortField{defaultModerationSort}, *pag,
+		db.WithWhere("deleted_at IS NULL"),
+		db.WithWhere("moderation_status = ?", entity.ReviewFlagged),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get flagged reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// ModerateReview restore flagged review or remove it for good
+func (u *ReviewUsecase) ModerateReview(ctx context.Context, username string, reviewID uuid.UUID, decision entity.ReviewDecision) (*entity.BidRewiew, error) {
+	if err := u.checkModerator(ctx, username); err != nil {
+		return nil, err
+	}
+
+	var moderated *entity.BidRewiew
+	err := u.tenderUsecase.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		review, err := u.lockReview(ctx, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if review.ModerationStatus != entity.ReviewFlagged {
+			return entity.ErrReviewNotFlagged
+		}
+
+		fields := map[string]any{"moderated_at": time.Now()}
+		switch decision {
+		case entity.ReviewRestore:
+			fields["moderation_status"] = entity.ReviewVisible
+			fields["flag_reason"] = nil
+			fields["flagged_at"] = nil
+		case entity.ReviewRemove:
+			fields["moderation_status"] = entity.ReviewRemoved
+		}
+
+		moderated, err = u.bidRepo.UpdateFeedback(ctx, reviewID, fields)
+		if err != nil {
+			return fmt.Errorf("moderate review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moderated, nil
+}
+
+func (u *ReviewUsecase) checkModerator(ctx context.Context, username string) error {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if !slices.Contains(u.cfg.Moderators, user.Username) {
+		return entity.ErrUserPermissionReview
+	}
+	return nil
+}
+
+// lockOwnReview lock review written by actor who still can review the bid
+func (u *ReviewUsecase) lockOwnReview(ctx context.Context, username string, reviewID uuid.UUID) (*entity.BidRewiew, error) {
+	review, err := u.lockReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, _, err := u.bidUsecase.checkBidPermission(ctx, username, review.BidID, authz.ActionBidReview, entity.ErrUserPermissionReview)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.ActorID == nil || *review.ActorID != actor.Ref().ID {
+		return nil, entity.ErrUserPermissionReview
+	}
+
+	return review, nil
+}
+
+// lockBidAuthorReview lock review to bid which actor can edit, i.e. acts for bid author
+func (u *ReviewUsecase) lockBidAuthorReview(ctx context.Context, username string, reviewID uuid.UUID) (*entity.BidRewiew, error) {
+	review, err := u.lockReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := u.bidUsecase.checkBidPermission(ctx, username, review.BidID, authz.ActionBidEdit, entity.ErrUserPermissionReview); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (u *ReviewUsecase) lockReview(ctx context.Context, reviewID uuid.UUID) (*entity.BidRewiew, error) {
+	reviews, err := u.bidRepo.GetFeedbacksByFilter(ctx,
+		db.WithWhere("id = ?", reviewID),
+		db.WithWhere("deleted_at IS NULL"),
+		db.WithLockForUpdate(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get review: %w", err)
+	}
+	if len(reviews) == 0 {
+		return nil, entity.ErrReviewNotFound
+	}
+
+	return &reviews[0], nil
+}
@@ -1,37 +1,64 @@
 package usecases
 
 import (
+	"avito/internal/authz"
 	db "avito/internal/db/repos"
 	"avito/internal/entity"
 	"avito/internal/usecases/repos"
 	"context"
+	"encoding/json"
 	"fmt"
-	"slices"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+var defaultTenderSort = entity.SortField{Field: "name"}
+
 type TenderUsecase struct {
 	tenderRepo repos.TenderRepo
+	orgRepo    repos.OrganizationRepo
+	userRepo   repos.UserRepo
+	outboxRepo repos.OutboxRepo
+	txManager  repos.TxManager
+	authorizer authz.Authorizer
 }
 
-func NewTenderUsecase(tenderRepo repos.TenderRepo) *TenderUsecase {
+func NewTenderUsecase(
+	tenderRepo repos.TenderRepo,
+	orgRepo repos.OrganizationRepo,
+	userRepo repos.UserRepo,
+	outboxRepo repos.OutboxRepo,
+	txManager repos.TxManager,
+	authorizer authz.Authorizer,
+) *TenderUsecase {
 	return &TenderUsecase{
 		tenderRepo: tenderRepo,
+		orgRepo:    orgRepo,
+		userRepo:   userRepo,
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+		authorizer: authorizer,
 	}
 }
 
 func (u *TenderUsecase) CreateTender(ctx context.Context, username string, tender *entity.Tender) (*entity.Tender, error) {
-	ok, err := u.checkUserResponsibleOrg(ctx, username, tender.OrganizationID)
+	actor, err := u.getActor(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("check user permission: %w", err)
+		return nil, fmt.Errorf("get actor: %w", err)
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionCreateTender
+
+	if err := u.authorize(ctx, actor, authz.ActionTenderCreate, authz.OrganizationResource(tender.OrganizationID), entity.ErrUserPermissionCreateTender); err != nil {
+		return nil, err
+	}
+
+	if tender.DeadlinePassed(time.Now()) {
+		return nil, entity.ErrDeadlinePast
 	}
 
 	tender.Version = 1
 	tender.Status = entity.Created
+	setTenderActor(tender, actor)
 
 	tender, err = u.tenderRepo.CreateTender(ctx, tender)
 	if err != nil {
@@ -41,43 +68,58 @@ func (u *TenderUsecase) CreateTender(ctx context.Context, username string, tende
 	return tender, nil
 }
 
-func (u *TenderUsecase) GetTenders(ctx context.Context, serviceTypes []entity.TenderServiceType, pag *entity.Pagination) ([]entity.Tender, error) {
-	conds := []db.FilterOption{}
-	if serviceTypes != nil {
-		conds = append(conds, db.WithWhere("service_type IN ?", serviceTypes))
-	}
-	conds = append(conds,
-		db.WithWhere("status = ?", entity.Published),
-		db.WithOrder("name asc"),
-		db.WithPagination(*pag),
-	)
-
-	tenders, err := u.tenderRepo.GetTendersByFilter(ctx, conds...)
+func (u *TenderUsecase) GetTenders(ctx context.Context, serviceTypes []entity.TenderServiceType, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Tender], error) {
+	tenders, err := u.tenderRepo.GetTendersPage(ctx, query.SortOr(defaultTenderSort), *pag, publicTendersFilters(serviceTypes, query)...)
 	if err != nil {
 		return nil, fmt.Errorf("get tenders: %w", err)
 	}
 
+	if err := u.setOrganizationRatings(ctx, tenders.Items); err != nil {
+		return nil, err
+	}
+
 	return tenders, nil
 }
 
-func (u *TenderUsecase) GetMyTenders(ctx context.Context, username string, pag *entity.Pagination) ([]entity.Tender, error) {
-	_, userOrgsIDs, err := u.getUserAndUserOrgsIDs(ctx, username)
+func (u *TenderUsecase) GetMyTenders(ctx context.Context, username string, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.Tender], error) {
+	actor, err := u.getActor(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("get user orgs ids: %w", err)
+		return nil, fmt.Errorf("get actor: %w", err)
 	}
 
-	tenders, err := u.tenderRepo.GetTendersByFilter(ctx,
-		db.WithWhere("organization_id IN ?", userOrgsIDs),
-		db.WithPagination(*pag),
-		db.WithOrder("name asc"),
-	)
+	tenders, err := u.tenderRepo.GetTendersPage(ctx, query.SortOr(defaultTenderSort), *pag, myTendersFilters(actor, query)...)
 	if err != nil {
 		return nil, fmt.Errorf("get tenders: %w", err)
 	}
 
+	if err := u.setOrganizationRatings(ctx, tenders.Items); err != nil {
+		return nil, err
+	}
+
 	return tenders, nil
 }
 
+// ExportTenders pass published tenders to fn in list order without paging, rows are streamed from db
+func (u *TenderUsecase) ExportTenders(ctx context.Context, serviceTypes []entity.TenderServiceType, query *entity.ListQuery, fn func(*entity.Tender) error) error {
+	if err := u.tenderRepo.StreamTenders(ctx, query.SortOr(defaultTenderSort), fn, publicTendersFilters(serviceTypes, query)...); err != nil {
+		return fmt.Errorf("stream tenders: %w", err)
+	}
+	return nil
+}
+
+// ExportMyTenders pass tenders of user organizations to fn in list order without paging, rows are streamed from db
+func (u *TenderUsecase) ExportMyTenders(ctx context.Context, username string, query *entity.ListQuery, fn func(*entity.Tender) error) error {
+	actor, err := u.getActor(ctx, username)
+	if err != nil {
+		return fmt.Errorf("get actor: %w", err)
+	}
+
+	if err := u.tenderRepo.StreamTenders(ctx, query.SortOr(defaultTenderSort), fn, myTendersFilters(actor, query)...); err != nil {
+		return fmt.Errorf("stream tenders: %w", err)
+	}
+	return nil
+}
+
 func (u *TenderUsecase) GetTenderStatus(ctx context.Context, username string, tenderID uuid.UUID) (entity.TenderStatusType, error) {
 	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
 	if err != nil {
@@ -88,83 +130,192 @@ func (u *TenderUsecase) GetTenderStatus(ctx context.Context, username string, te
 		return tender.Status, nil
 	}
 
-	if username == "" {
-		return "", entity.ErrUserNotSpecified
-	}
-
-	ok, err := u.checkPermissionForTender(ctx, username, tender.Id)
+	actor, err := u.getActor(ctx, username)
 	if err != nil {
-		return "", fmt.Errorf("check user permission: %w", err)
+		return "", fmt.Errorf("get actor: %w", err)
 	}
-	if !ok {
-		return "", entity.ErrUserPermissionTender
+
+	if err := u.authorize(ctx, actor, authz.ActionTenderView, authz.TenderResource(tender), entity.ErrUserPermissionTender); err != nil {
+		return "", err
 	}
 
 	return tender.Status, nil
 }
 
 func (u *TenderUsecase) UpdateTenderStatus(ctx context.Context, username string, tenderID uuid.UUID, status entity.TenderStatusType) (*entity.Tender, error) {
-	ok, err := u.checkPermissionForTender(ctx, username, tenderID)
+	if _, err := u.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderPublish); err != nil {
+		return nil, err
+	}
+
+	return u.setTenderStatus(ctx, tenderID, status)
+}
+
+// UpdateTendersStatus change status of tenders in batch, atomic batch is applied all or nothing
+func (u *TenderUsecase) UpdateTendersStatus(ctx context.Context, username string, tenderIDs uuid.UUIDs, status entity.TenderStatusType, atomic bool) ([]entity.BatchItem[entity.Tender], error) {
+	actor, err := u.getActor(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("check user permission: %w", err)
+		return nil, fmt.Errorf("get actor: %w", err)
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionTender
+
+	tenders, err := u.tenderRepo.GetTendersByFilter(ctx, db.WithWhere("id IN ?", tenderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("get tenders by ids: %w", err)
+	}
+	byID := map[uuid.UUID]*entity.Tender{}
+	for i := range tenders {
+		byID[tenders[i].Id] = &tenders[i]
 	}
 
-	if err := u.tenderRepo.UpdateTenderStatus(ctx, tenderID, status); err != nil {
-		return nil, fmt.Errorf("update tender status %w", err)
+	checks := make([]error, len(tenderIDs))
+	for i, tenderID := range tenderIDs {
+		tender, ok := byID[tenderID]
+		if !ok {
+			checks[i] = entity.ErrTenderNotFound
+			continue
+		}
+		checks[i] = u.authorize(ctx, actor, authz.ActionTenderPublish, authz.TenderResource(tender), entity.ErrUserPermissionTender)
 	}
 
-	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+	return runBatch(ctx, u.txManager, tenderIDs, checks, atomic, func(ctx context.Context, i int) (*entity.Tender, error) {
+		return u.setTenderStatus(ctx, tenderIDs[i], status)
+	})
+}
+
+func (u *TenderUsecase) setTenderStatus(ctx context.Context, tenderID uuid.UUID, status entity.TenderStatusType) (*entity.Tender, error) {
+	var tender *entity.Tender
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.tenderRepo.UpdateTenderStatus(ctx, tenderID, status); err != nil {
+			return fmt.Errorf("update tender status %w", err)
+		}
+
+		var err error
+		tender, err = u.tenderRepo.GetTenderByID(ctx, tenderID)
+		if err != nil {
+			return fmt.Errorf("get tender by id: %w", err)
+		}
+
+		return u.publishTenderStatus(ctx, tender)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get tender by id: %w", err)
+		return nil, err
 	}
 
 	return tender, nil
 }
 
 func (u *TenderUsecase) PatchTender(ctx context.Context, username string, tenderID uuid.UUID, patchTender *entity.Tender) (*entity.Tender, error) {
-	ok, err := u.checkPermissionForTender(ctx, username, tenderID)
+	actor, err := u.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderEdit)
 	if err != nil {
-		return nil, fmt.Errorf("check user permission: %w", err)
+		return nil, err
 	}
-	if !ok {
-		return nil, entity.ErrUserPermissionTender
+	setTenderActor(patchTender, actor)
+
+	if patchTender.DeadlinePassed(time.Now()) {
+		return nil, entity.ErrDeadlinePast
 	}
 
-	tender, err := u.tenderRepo.PatchTender(ctx, tenderID, patchTender)
+	var tender *entity.Tender
+	err = u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		prev, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+		if err != nil {
+			return fmt.Errorf("get tender by id: %w", err)
+		}
+
+		tender, err = u.tenderRepo.PatchTender(ctx, tenderID, patchTender)
+		if err != nil {
+			return fmt.Errorf("patch tender: %w", err)
+		}
+		if err := checkSealedDeadline(prev, tender); err != nil {
+			return err
+		}
+
+		return u.publishEvent(ctx, entity.EventTenderUpdated, tender, tender.OrganizationID)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("patch tender: %w", err)
+		return nil, err
 	}
 
 	return tender, nil
 }
 
 func (u *TenderUsecase) RollbackTender(ctx context.Context, username string, tenderID uuid.UUID, version int) (*entity.Tender, error) {
-	ok, err := u.checkPermissionForTender(ctx, username, tenderID)
+	actor, err := u.checkPermissionForTender(ctx, username, tenderID, authz.ActionTenderEdit)
 	if err != nil {
-		return nil, fmt.Errorf("check user permission: %w", err)
-	}
-	if !ok {
-		return nil, entity.ErrUserPermissionTender
+		return nil, err
 	}
 
-	tender, err := u.tenderRepo.RollbackTender(ctx, tenderID, version)
+	var tender *entity.Tender
+	err = u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		prev, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
+		if err != nil {
+			return fmt.Errorf("get tender by id: %w", err)
+		}
+
+		tender, err = u.tenderRepo.RollbackTender(ctx, tenderID, version, actor.Ref())
+		if err != nil {
+			return fmt.Errorf("rollback tender: %w", err)
+		}
+		if err := checkSealedDeadline(prev, tender); err != nil {
+			return err
+		}
+
+		return u.publishEvent(ctx, entity.EventTenderUpdated, tender, tender.OrganizationID)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("rollback tender: %w", err)
+		return nil, err
 	}
 
 	return tender, nil
 }
 
+// checkSealedDeadline forbid moving deadline of sealed tender earlier, bids would be opened by deadline
+// without quorum and bidders would lose submission time, tender without deadline opens only by quorum
+func checkSealedDeadline(prev *entity.Tender, next *entity.Tender) error {
+	if !prev.BidsSealed() || next.Deadline == nil {
+		return nil
+	}
+	if prev.Deadline == nil || next.Deadline.Before(*prev.Deadline) {
+		return entity.ErrDeadlineEarlier
+	}
+	return nil
+}
+
+// getActiveUser get user, deactivated users are blocked everywhere
+func (u *TenderUsecase) getActiveUser(ctx context.Context, username string) (*entity.User, error) {
+	if username == "" {
+		return nil, entity.ErrUserNotSpecified
+	}
+
+	user, err := u.userRepo.GetUserByUserName(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user by user name: %w", err)
+	}
+	if !user.Active {
+		return nil, entity.ErrUserDeactivated
+	}
+
+	return user, nil
+}
+
+func (u *TenderUsecase) getActiveUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	user, err := u.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+	if !user.Active {
+		return nil, entity.ErrUserDeactivated
+	}
+
+	return user, nil
+}
+
 func (u *TenderUsecase) getUserAndUserOrgsIDs(ctx context.Context, username string) (*entity.User, uuid.UUIDs, error) {
-	user, err := u.tenderRepo.GetUserByUserName(ctx, username)
+	user, err := u.getActiveUser(ctx, username)
 	if err != nil {
-		return nil, nil, fmt.Errorf("get user by user name: %w", err)
+		return nil, nil, err
 	}
 
-	userOrgsUUIDs, err := u.tenderRepo.GetUserOrgsUUIDs(ctx, user.Id)
+	userOrgsUUIDs, err := u.orgRepo.GetUserOrgsUUIDs(ctx, user.Id)
 	if err != nil {
 		return nil, nil, fmt.Errorf("get user organizations: %w", err)
 	}
@@ -172,24 +323,156 @@ func (u *TenderUsecase) getUserAndUserOrgsIDs(ctx context.Context, username stri
 	return user, userOrgsUUIDs, nil
 }
 
-func (u *TenderUsecase) checkUserResponsibleOrg(ctx context.Context, username string, orgID uuid.UUID) (bool, error) {
-	_, userResponsibleOrgsIDs, err := u.getUserAndUserOrgsIDs(ctx, username)
+// getActor load user with memberships once for all authorization checks in request,
+// request authenticated by api key acts as the key
+func (u *TenderUsecase) getActor(ctx context.Context, username string) (*authz.Actor, error) {
+	if key := authz.ApiKeyFromContext(ctx); key != nil {
+		return &authz.Actor{ApiKey: key}, nil
+	}
+
+	user, err := u.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := u.orgRepo.GetUserMemberships(ctx, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("get user memberships: %w", err)
+	}
+
+	return &authz.Actor{User: *user, Memberships: memberships}, nil
+}
+
+// authorize return deny error if actor cant do action with resource
+func (u *TenderUsecase) authorize(ctx context.Context, actor *authz.Actor, action authz.Action, resource authz.Resource, deny error) error {
+	ok, err := u.authorizer.Can(ctx, actor, action, resource)
 	if err != nil {
-		return false, fmt.Errorf("get user and user orgs id: %w", err)
+		return fmt.Errorf("authorize %s: %w", action, err)
+	}
+	if !ok {
+		return deny
 	}
 
-	return slices.Contains(userResponsibleOrgsIDs, orgID), nil
+	return nil
 }
 
-func (u *TenderUsecase) checkPermissionForTender(ctx context.Context, username string, tenderID uuid.UUID) (bool, error) {
+func (u *TenderUsecase) checkPermissionForTender(ctx context.Context, username string, tenderID uuid.UUID, action authz.Action) (*authz.Actor, error) {
 	tender, err := u.tenderRepo.GetTenderByID(ctx, tenderID)
 	if err != nil {
-		return false, fmt.Errorf("get tender by id: %w", err)
+		return nil, fmt.Errorf("get tender by id: %w", err)
+	}
+
+	actor, err := u.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	if err := u.authorize(ctx, actor, action, authz.TenderResource(tender), entity.ErrUserPermissionTender); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
+
+// getVoters get members of organization whom policy allows to vote for bids
+func (u *TenderUsecase) getVoters(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	return u.getMembersAllowed(ctx, orgID, authz.ActionBidVote)
+}
+
+// getMembersAllowed get members of organization whom policy allows action in it
+func (u *TenderUsecase) getMembersAllowed(ctx context.Context, orgID uuid.UUID, action authz.Action) ([]uuid.UUID, error) {
+	members, err := u.orgRepo.GetOrgMemberships(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get org members: %w", err)
+	}
+
+	allowed := []uuid.UUID{}
+	for _, m := range members {
+		member := &authz.Actor{User: entity.User{Id: m.UserID}, Memberships: []entity.Membership{m}}
+		ok, err := u.authorizer.Can(ctx, member, action, authz.OrganizationResource(orgID))
+		if err != nil {
+			return nil, fmt.Errorf("authorize member: %w", err)
+		}
+		if ok {
+			allowed = append(allowed, m.UserID)
+		}
+	}
+
+	return allowed, nil
+}
+
+// setOrganizationRatings set rating of tender organizations, organizations without reviews get empty rating
+func (u *TenderUsecase) setOrganizationRatings(ctx context.Context, tenders []entity.Tender) error {
+	if len(tenders) == 0 {
+		return nil
+	}
+
+	orgIDs := uuid.UUIDs{}
+	for _, tender := range tenders {
+		orgIDs = append(orgIDs, tender.OrganizationID)
+	}
+
+	ratings, err := u.orgRepo.GetOrgRatings(ctx, orgIDs)
+	if err != nil {
+		return fmt.Errorf("get organization ratings: %w", err)
+	}
+
+	for i := range tenders {
+		rating := ratings[tenders[i].OrganizationID]
+		tenders[i].OrganizationRating = &rating
 	}
+	return nil
+}
 
-	ok, err := u.checkUserResponsibleOrg(ctx, username, tender.OrganizationID)
+func publicTendersFilters(serviceTypes []entity.TenderServiceType, query *entity.ListQuery) []db.FilterOption {
+	conds := []db.FilterOption{}
+	if serviceTypes != nil {
+		conds = append(conds, db.WithWhere("service_type IN ?", serviceTypes))
+	}
+	return append(conds,
+		db.WithWhere("status = ?", entity.Published),
+		db.WithFilters(query.Filters),
+	)
+}
+
+func myTendersFilters(actor *authz.Actor, query *entity.ListQuery) []db.FilterOption {
+	return []db.FilterOption{
+		db.WithWhere("organization_id IN ?", actor.OrganizationIDs()),
+		db.WithFilters(query.Filters),
+	}
+}
+
+// publishEvent write event to outbox, must be called within transaction of the change
+func (u *TenderUsecase) publishEvent(ctx context.Context, eventType entity.EventType, payload any, orgIDs ...uuid.UUID) error {
+	data, err := json.Marshal(payload)
 	if err != nil {
-		return false, fmt.Errorf("check user permission: %w", err)
+		return fmt.Errorf("marshal %s payload: %w", eventType, err)
 	}
-	return ok, nil
+
+	if _, err := u.outboxRepo.AddEvent(ctx, &entity.Event{
+		Type:            eventType,
+		Payload:         data,
+		OrganizationIDs: orgIDs,
+	}); err != nil {
+		return fmt.Errorf("add %s event: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// publishTenderStatus publish event if tender got status integrators are interested in
+func (u *TenderUsecase) publishTenderStatus(ctx context.Context, tender *entity.Tender) error {
+	switch tender.Status {
+	case entity.Published:
+		return u.publishEvent(ctx, entity.EventTenderPublished, tender, tender.OrganizationID)
+	case entity.Closed:
+		return u.publishEvent(ctx, entity.EventTenderClosed, tender, tender.OrganizationID)
+	}
+	return nil
+}
+
+func setTenderActor(tender *entity.Tender, actor *authz.Actor) {
+	ref := actor.Ref()
+	tender.ActorType = ref.Type
+	tender.ActorID = &ref.ID
 }
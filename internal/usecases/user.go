@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserUsecase struct {
+	userRepo      repos.UserRepo
+	orgRepo       repos.OrganizationRepo
+	tenderUsecase *TenderUsecase
+}
+
+func NewUserUsecase(userRepo repos.UserRepo, orgRepo repos.OrganizationRepo, tenderUsecase *TenderUsecase) *UserUsecase {
+	return &UserUsecase{
+		userRepo:      userRepo,
+		orgRepo:       orgRepo,
+		tenderUsecase: tenderUsecase,
+	}
+}
+
+func (u *UserUsecase) Register(ctx context.Context, user *entity.User, password string) (*entity.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+	user.PasswordHash = string(hash)
+
+	user, err = u.userRepo.CreateUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (u *UserUsecase) GetUser(ctx context.Context, username string) (*entity.User, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (u *UserUsecase) GetMe(ctx context.Context, username string) (*entity.UserProfile, error) {
+	actor, err := u.tenderUsecase.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	orgs, err := u.orgRepo.GetOrgsByFilter(ctx,
+		db.WithWhere("id IN ?", actor.OrganizationIDs()),
+		db.WithOrder("name asc"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get user orgs: %w", err)
+	}
+
+	profile := entity.UserProfile{
+		User:          actor.User,
+		Email:         actor.User.Email,
+		Locale:        actor.User.Locale,
+		Organizations: []entity.UserMembership{},
+	}
+	for _, org := range orgs {
+		role, _ := actor.Role(org.Id)
+		profile.Organizations = append(profile.Organizations, entity.UserMembership{
+			Organization: org,
+			Role:         role,
+		})
+	}
+
+	return &profile, nil
+}
+
+func (u *UserUsecase) PatchMe(ctx context.Context, username string, patchUser *entity.User) (*entity.User, error) {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	user, err = u.userRepo.PatchUser(ctx, user.Id, patchUser)
+	if err != nil {
+		return nil, fmt.Errorf("patch user: %w", err)
+	}
+
+	return user, nil
+}
+
+// DeactivateMe block account, password is required if it was set at registration
+func (u *UserUsecase) DeactivateMe(ctx context.Context, username string, password string) error {
+	user, err := u.tenderUsecase.getActiveUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if user.PasswordHash != "" {
+		err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return entity.ErrInvalidPassword
+		}
+		if err != nil {
+			return fmt.Errorf("compare password: %w", err)
+		}
+	}
+
+	if err := u.userRepo.DeactivateUser(ctx, user.Id); err != nil {
+		return fmt.Errorf("deactivate user: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"avito/internal/authz"
+	db "avito/internal/db/repos"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var (
+	defaultWebhookSort  = entity.SortField{Field: "created_at", Desc: true}
+	defaultDeliverySort = entity.SortField{Field: "created_at", Desc: true}
+)
+
+type WebhookUsecase struct {
+	webhookRepo   repos.WebhookRepo
+	orgRepo       repos.OrganizationRepo
+	tenderUsecase *TenderUsecase
+}
+
+func NewWebhookUsecase(webhookRepo repos.WebhookRepo, orgRepo repos.OrganizationRepo, tenderUsecase *TenderUsecase) *WebhookUsecase {
+	return &WebhookUsecase{
+		webhookRepo:   webhookRepo,
+		orgRepo:       orgRepo,
+		tenderUsecase: tenderUsecase,
+	}
+}
+
+func (u *WebhookUsecase) CreateWebhook(ctx context.Context, username string, orgID uuid.UUID, webhook *entity.Webhook) (*entity.Webhook, error) {
+	actor, err := u.checkManageWebhooks(ctx, username, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.OrganizationID = orgID
+	webhook.CreatorID = actor.User.Id
+
+	webhook, err = u.webhookRepo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (u *WebhookUsecase) GetWebhooks(ctx context.Context, username string, orgID uuid.UUID, pag *entity.Pagination) (*entity.Page[entity.Webhook], error) {
+	if _, err := u.checkManageWebhooks(ctx, username, orgID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := u.webhookRepo.GetWebhooksPage(ctx, []entity.SortField{defaultWebhookSort}, *pag,
+		db.WithWhere("organization_id = ?", orgID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (u *WebhookUsecase) DeleteWebhook(ctx context.Context, username string, webhookID uuid.UUID) error {
+	webhook, err := u.webhookRepo.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("get webhook by id: %w", err)
+	}
+
+	actor, err := u.checkManageWebhooks(ctx, username, webhook.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.webhookRepo.DeleteWebhook(ctx, webhookID, actor.User.Id); err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (u *WebhookUsecase) GetDeliveries(ctx context.Context, username string, webhookID uuid.UUID, pag *entity.Pagination, query *entity.ListQuery) (*entity.Page[entity.WebhookDelivery], error) {
+	webhook, err := u.webhookRepo.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook by id: %w", err)
+	}
+
+	if _, err := u.checkManageWebhooks(ctx, username, webhook.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.webhookRepo.GetDeliveriesPage(ctx, query.SortOr(defaultDeliverySort), *pag,
+		db.WithWhere("webhook_id = ?", webhookID),
+		db.WithFilters(query.Filters),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver send event of delivery again as new delivery
+func (u *WebhookUsecase) Redeliver(ctx context.Context, username string, webhookID uuid.UUID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error) {
+	webhook, err := u.webhookRepo.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook by id: %w", err)
+	}
+
+	if _, err := u.checkManageWebhooks(ctx, username, webhook.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := u.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("get delivery by id: %w", err)
+	}
+	if delivery.WebhookID != webhookID {
+		return nil, entity.ErrDeliveryNotFound
+	}
+
+	delivery, err = u.webhookRepo.Redeliver(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("redeliver: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// EnqueueDeliveries is subscriber of all events, it creates deliveries sent later by dispatcher
+func (u *WebhookUsecase) EnqueueDeliveries(ctx context.Context, event *entity.Event) error {
+	if err := u.webhookRepo.CreateDeliveries(ctx, event); err != nil {
+		return fmt.Errorf("create deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (u *WebhookUsecase) checkManageWebhooks(ctx context.Context, username string, orgID uuid.UUID) (*authz.Actor, error) {
+	if _, err := u.orgRepo.GetOrgByID(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("get org by id: %w", err)
+	}
+
+	actor, err := u.tenderUsecase.getActor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+
+	if err := u.tenderUsecase.authorize(ctx, actor, authz.ActionWebhookManage, authz.OrganizationResource(orgID), entity.ErrUserPermissionOrg); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"avito/internal/config"
+	"avito/internal/entity"
+	"avito/internal/usecases/repos"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	webhookBatchSize = 50
+	maxErrorLen      = 500
+)
+
+// WebhookDispatcher send pending webhook deliveries with retries.
+// Several instances can run at once, rows are claimed with skip locked.
+type WebhookDispatcher struct {
+	webhookRepo repos.WebhookRepo
+	client      *http.Client
+	cfg         config.Webhook
+}
+
+func NewWebhookDispatcher(webhookRepo repos.WebhookRepo, cfg *config.Webhook) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      newWebhookClient(cfg.Timeout),
+		cfg:         *cfg,
+	}
+}
+
+// Run poll deliveries until ctx is done
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.dispatch(ctx); err != nil {
+				log.Printf("webhook dispatch: %v", err)
+			}
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context) error {
+	// claimed deliveries are hidden until all of them are surely sent or timed out
+	tasks, err := d.webhookRepo.ClaimDeliveries(ctx, webhookBatchSize, 2*d.cfg.Timeout)
+	if err != nil {
+		return fmt.Errorf("claim deliveries: %w", err)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, task := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivery := d.deliver(ctx, &task)
+			if err := d.webhookRepo.SaveDeliveryAttempt(ctx, delivery); err != nil {
+				log.Printf("save webhook delivery %s: %v", delivery.Id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// deliver make one attempt and return delivery with its result and next attempt time
+func (d *WebhookDispatcher) deliver(ctx context.Context, task *entity.DeliveryTask) *entity.WebhookDelivery {
+	delivery := task.Delivery
+	delivery.Attempts++
+
+	status, err := d.send(ctx, task)
+	delivery.ResponseStatus = status
+	if err == nil {
+		delivery.Status = entity.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		return &delivery
+	}
+
+	delivery.LastError = truncateError(err)
+
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		delivery.Status = entity.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		return &delivery
+	}
+
+	next := time.Now().Add(d.cfg.RetryDelay << (delivery.Attempts - 1))
+	delivery.NextAttemptAt = &next
+
+	return &delivery
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, task *entity.DeliveryTask) (*int, error) {
+	body, err := json.Marshal(task.Event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event: %w", err)
+	}
+
+	// hooks created before https was required
+	if u, err := url.Parse(task.Webhook.URL); err != nil || u.Scheme != "https" {
+		return nil, errWebhookNotHTTPS
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(task.Event.Type))
+	req.Header.Set("X-Webhook-Delivery", task.Delivery.Id.String())
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhook(task.Webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return &resp.StatusCode, nil
+}
+
+var (
+	errWebhookNotHTTPS   = errors.New("webhook url must be https")
+	errWebhookAddress    = errors.New("webhook address is not public")
+	errWebhookRedirected = errors.New("webhook redirects are not followed")
+)
+
+// newWebhookClient is client which reaches only public addresses, so hooks cant probe internal network.
+// Address is checked after dns resolution right before connect, redirects are refused for the same reason
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return errWebhookAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return errWebhookRedirected
+		},
+	}
+}
+
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// signWebhook hmac-sha256 of "<timestamp>.<body>", timestamp protects from replay
+func signWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}